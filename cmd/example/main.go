@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"math"
+	"path/filepath"
 
 	"github.com/gremour/grue"
 	"github.com/gremour/grue/particles"
@@ -75,6 +76,34 @@ func runUI() {
 	le.Place(grue.V(10, 10))
 	polish(le.Panel)
 
+	pn1.ContextMenu = []grue.MenuOption{
+		{
+			Text: "&Clear",
+			Handler: func(pm *grue.PopupMenu) bool {
+				le.Text = ""
+				pn1.Text = ""
+				return true
+			},
+		},
+		{Separator: true},
+		{Text: "&Smile", Shortcut: ":)", Checkable: true, Checked: true, Group: "face"},
+		{Text: "&Frown", Shortcut: ":(", Checkable: true, Group: "face"},
+	}
+
+	cb := grue.NewComboBox(pn1, grue.Base{
+		Rect:            grue.R0(230, 40),
+		PlaceholderText: "choose",
+	},
+		grue.MenuOption{ID: "sword", Text: "Sword"},
+		grue.MenuOption{ID: "axe", Text: "Axe"},
+		grue.MenuOption{ID: "bow", Text: "Bow", Disabled: true},
+		grue.MenuOption{ID: "staff", Text: "Staff", Image: "grue-logo20"},
+	)
+	cb.Place(grue.V(10, 60))
+	cb.OnSelected = func(i int, id string) {
+		fmt.Printf("combo box selected %v (%v)\n", i, id)
+	}
+
 	bt1 := grue.NewPushButton(pn, grue.Base{
 		Rect: grue.R0(120, 40),
 		Text: "Hello",
@@ -143,6 +172,18 @@ func runUI() {
 		)
 	}
 
+	// Load image sheets dragged in from file manager.
+	s.SetOnFilesDropped(func(paths []string, pos grue.Vec) {
+		for _, p := range paths {
+			if filepath.Ext(p) != ".json" {
+				continue
+			}
+			if err := s.InitImages(p); err != nil {
+				fmt.Printf("loading %v: %v\n", p, err)
+			}
+		}
+	})
+
 	s.SetEvents(func() {
 	})
 
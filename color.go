@@ -1,6 +1,12 @@
 package grue
 
-import "image/color"
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+)
 
 // RGB constructs color with float R, G, B components in range 0..1.
 func RGB(r, g, b float64) color.Color {
@@ -34,3 +40,154 @@ func ColorInterpolate(c1, c2 color.Color, dist float64) color.Color {
 		(float64(a2)/0xffff-float64(a1)/0xffff)*dist+float64(a1)/0xffff,
 	)
 }
+
+// ColorFade returns color with opacity multiplied by alpha from 0 to 1.
+func ColorFade(c color.Color, alpha float64) color.Color {
+	r, g, b, a := c.RGBA()
+	return color.RGBA64{
+		uint16(float64(r) * alpha),
+		uint16(float64(g) * alpha),
+		uint16(float64(b) * alpha),
+		uint16(float64(a) * alpha),
+	}
+}
+
+// ColorMultiply returns color with components of both colors
+// multiplied. Nil color is treated as opaque white.
+func ColorMultiply(c1, c2 color.Color) color.Color {
+	if c1 == nil {
+		return c2
+	}
+	if c2 == nil {
+		return c1
+	}
+	r1, g1, b1, a1 := c1.RGBA()
+	r2, g2, b2, a2 := c2.RGBA()
+	return color.RGBA64{
+		uint16(r1 * r2 / 0xffff),
+		uint16(g1 * g2 / 0xffff),
+		uint16(b1 * b2 / 0xffff),
+		uint16(a1 * a2 / 0xffff),
+	}
+}
+
+// HSVA constructs color from hue (degrees 0..360), saturation,
+// value and alpha in range 0..1.
+func HSVA(h, s, v, a float64) color.Color {
+	h = math.Mod(h, 360)
+	if h < 0 {
+		h += 360
+	}
+	c := v * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g, b = c, x, 0
+	case h < 120:
+		r, g, b = x, c, 0
+	case h < 180:
+		r, g, b = 0, c, x
+	case h < 240:
+		r, g, b = 0, x, c
+	case h < 300:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+	m := v - c
+	return color.NRGBA{
+		uint8(math.Round(0xff * (r + m))),
+		uint8(math.Round(0xff * (g + m))),
+		uint8(math.Round(0xff * (b + m))),
+		uint8(math.Round(0xff * a)),
+	}
+}
+
+// ColorComponents returns R, G, B, A components of color
+// in range 0..1, not premultiplied by alpha.
+func ColorComponents(c color.Color) (r, g, b, a float64) {
+	ri, gi, bi, ai := c.RGBA()
+	if ai == 0 {
+		return 0, 0, 0, 0
+	}
+	a = float64(ai) / 0xffff
+	un := func(v uint32) float64 {
+		return math.Min(float64(v)/float64(ai), 1)
+	}
+	return un(ri), un(gi), un(bi), a
+}
+
+// ColorToHSVA returns hue (degrees 0..360), saturation,
+// value and alpha (0..1) of color.
+func ColorToHSVA(c color.Color) (h, s, v, a float64) {
+	r, g, b, a := ColorComponents(c)
+	max := math.Max(r, math.Max(g, b))
+	min := math.Min(r, math.Min(g, b))
+	d := max - min
+	v = max
+	if max > 0 {
+		s = d / max
+	}
+	switch {
+	case d == 0:
+		h = 0
+	case max == r:
+		h = 60 * math.Mod((g-b)/d, 6)
+	case max == g:
+		h = 60 * ((b-r)/d + 2)
+	default:
+		h = 60 * ((r-g)/d + 4)
+	}
+	if h < 0 {
+		h += 360
+	}
+	return h, s, v, a
+}
+
+// ColorHex returns color as "#RRGGBBAA" string
+// (not premultiplied by alpha).
+func ColorHex(c color.Color) string {
+	r, g, b, a := ColorComponents(c)
+	u := func(v float64) uint8 {
+		return uint8(math.Round(v * 0xff))
+	}
+	return fmt.Sprintf("#%02X%02X%02X%02X", u(r), u(g), u(b), u(a))
+}
+
+// ParseColor parses color in forms "#RRGGBB", "#RRGGBBAA"
+// (leading # is optional) or "R, G, B" and "R, G, B, A"
+// with decimal components from 0 to 255.
+func ParseColor(str string) (color.Color, error) {
+	str = strings.TrimSpace(str)
+	var comps []uint64
+	if strings.Contains(str, ",") {
+		for _, f := range strings.Split(str, ",") {
+			v, err := strconv.ParseUint(strings.TrimSpace(f), 10, 8)
+			if err != nil {
+				return nil, fmt.Errorf("invalid color %q: %v", str, err)
+			}
+			comps = append(comps, v)
+		}
+	} else {
+		hex := strings.TrimPrefix(str, "#")
+		if len(hex)%2 != 0 {
+			return nil, fmt.Errorf("invalid color %q", str)
+		}
+		for i := 0; i < len(hex); i += 2 {
+			v, err := strconv.ParseUint(hex[i:i+2], 16, 8)
+			if err != nil {
+				return nil, fmt.Errorf("invalid color %q: %v", str, err)
+			}
+			comps = append(comps, v)
+		}
+	}
+	switch len(comps) {
+	case 3:
+		comps = append(comps, 0xff)
+	case 4:
+	default:
+		return nil, fmt.Errorf("invalid color %q", str)
+	}
+	return color.NRGBA{uint8(comps[0]), uint8(comps[1]), uint8(comps[2]), uint8(comps[3])}, nil
+}
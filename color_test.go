@@ -0,0 +1,85 @@
+package grue
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		str  string
+		want color.NRGBA
+	}{
+		{"#FF8000", color.NRGBA{0xff, 0x80, 0x00, 0xff}},
+		{"ff8000", color.NRGBA{0xff, 0x80, 0x00, 0xff}},
+		{"#10203040", color.NRGBA{0x10, 0x20, 0x30, 0x40}},
+		{"#abcdef", color.NRGBA{0xab, 0xcd, 0xef, 0xff}},
+		{"255, 128, 0", color.NRGBA{255, 128, 0, 255}},
+		{" 1,2,3,4 ", color.NRGBA{1, 2, 3, 4}},
+	}
+	for _, tt := range tests {
+		c, err := ParseColor(tt.str)
+		if err != nil {
+			t.Errorf("ParseColor(%q): unexpected error %v", tt.str, err)
+			continue
+		}
+		if c != tt.want {
+			t.Errorf("ParseColor(%q) = %v, want %v", tt.str, c, tt.want)
+		}
+	}
+}
+
+func TestParseColorInvalid(t *testing.T) {
+	for _, str := range []string{
+		"",
+		"#",
+		"#FFF",
+		"#FF80",
+		"#FF800",
+		"#GG0000",
+		"#1122334455",
+		"255, 128",
+		"256, 0, 0",
+		"1, 2, 3, 4, 5",
+		"-1, 0, 0",
+		"red",
+	} {
+		if c, err := ParseColor(str); err == nil {
+			t.Errorf("ParseColor(%q) = %v, want error", str, c)
+		}
+	}
+}
+
+func TestColorToHSVA(t *testing.T) {
+	tests := []struct {
+		c          color.Color
+		h, s, v, a float64
+	}{
+		{color.NRGBA{0, 0, 0, 0xff}, 0, 0, 0, 1},
+		{color.NRGBA{0xff, 0xff, 0xff, 0xff}, 0, 0, 1, 1},
+		{color.NRGBA{0x80, 0x80, 0x80, 0xff}, 0, 0, 0x80 / 255.0, 1},
+		{color.NRGBA{0xff, 0, 0, 0xff}, 0, 1, 1, 1},
+		{color.NRGBA{0xff, 0xff, 0, 0xff}, 60, 1, 1, 1},
+		{color.NRGBA{0, 0xff, 0, 0xff}, 120, 1, 1, 1},
+		{color.NRGBA{0, 0xff, 0xff, 0xff}, 180, 1, 1, 1},
+		{color.NRGBA{0, 0, 0xff, 0xff}, 240, 1, 1, 1},
+		{color.NRGBA{0xff, 0, 0xff, 0xff}, 300, 1, 1, 1},
+		{color.NRGBA{0xff, 0, 0x80, 0xff}, 330, 1, 1, 1},
+		// Components are not premultiplied by alpha.
+		{color.NRGBA{0, 0xff, 0, 0x80}, 120, 1, 1, 0x80 / 255.0},
+	}
+	const eps = 0.01
+	for _, tt := range tests {
+		h, s, v, a := ColorToHSVA(tt.c)
+		if math.Abs(h-tt.h) > 0.5 || math.Abs(s-tt.s) > eps ||
+			math.Abs(v-tt.v) > eps || math.Abs(a-tt.a) > eps {
+			t.Errorf("ColorToHSVA(%v) = %.2f, %.2f, %.2f, %.2f, want %.2f, %.2f, %.2f, %.2f",
+				tt.c, h, s, v, a, tt.h, tt.s, tt.v, tt.a)
+		}
+		// Converting back gives the same color.
+		if back := color.NRGBAModel.Convert(HSVA(h, s, v, a)); back != color.NRGBAModel.Convert(tt.c) {
+			t.Errorf("HSVA(ColorToHSVA(%v)) = %v", tt.c, back)
+		}
+	}
+}
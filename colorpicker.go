@@ -0,0 +1,300 @@
+package grue
+
+import (
+	"image/color"
+	"math"
+)
+
+// Part of color picker being dragged.
+type pickerPart int
+
+const (
+	pickerNone pickerPart = iota
+	pickerSV
+	pickerHue
+	pickerAlpha
+)
+
+// Size of checkerboard cells drawn under translucent colors.
+const pickerCheckerSize = 6
+
+// ColorPicker is a widget to choose color in HSV space.
+// It has saturation/value square, hue and alpha strips,
+// line edit for hex ("#RRGGBBAA") or decimal ("R, G, B, A")
+// input, preview swatch and palette of recent colors.
+type ColorPicker struct {
+	*Panel
+	// Edit is line edit with color text.
+	Edit *LineEdit
+	// Recent colors, the newest first. Color is added
+	// when user finishes dragging or enters text.
+	Recent []color.Color
+	// MaxRecent limits number of recent colors.
+	MaxRecent int
+
+	// OnColorChanged is called when color is changed by user.
+	OnColorChanged func(c color.Color)
+
+	h, s, v, a float64
+	drag       pickerPart
+}
+
+// Rectangles of color picker parts (screen coords).
+type pickerLayout struct {
+	sv, hue, alpha, preview Rect
+	recent                  []Rect
+}
+
+// NewColorPicker creates new color picker with opaque white color.
+func NewColorPicker(parent Widget, b Base) *ColorPicker {
+	cp := &ColorPicker{
+		Panel:     NewPanel(nil, b),
+		MaxRecent: 8,
+		v:         1,
+		a:         1,
+	}
+	InitWidget(parent, cp)
+	cp.Edit = NewLineEdit(cp, Base{})
+	cp.Edit.OnEditingFinished = cp.onEditingFinished
+	cp.layout()
+	cp.updateText()
+	cp.OnMouseDown = cp.onMouseDown
+	return cp
+}
+
+// Color returns current color (color.NRGBA).
+func (cp *ColorPicker) Color() color.Color {
+	return HSVA(cp.h, cp.s, cp.v, cp.a)
+}
+
+// SetColor sets current color. OnColorChanged is not called.
+func (cp *ColorPicker) SetColor(c color.Color) {
+	h, s, v, a := ColorToHSVA(c)
+	if s == 0 || v == 0 {
+		// Hue is undefined for grays, keep current one.
+		h = cp.h
+	}
+	cp.h, cp.s, cp.v, cp.a = h, s, v, a
+	cp.updateText()
+}
+
+// AddRecent adds color to the front of recent colors.
+func (cp *ColorPicker) AddRecent(c color.Color) {
+	r, g, b, a := c.RGBA()
+	recent := []color.Color{c}
+	for _, rc := range cp.Recent {
+		rr, rg, rb, ra := rc.RGBA()
+		if rr != r || rg != g || rb != b || ra != a {
+			recent = append(recent, rc)
+		}
+	}
+	if cp.MaxRecent > 0 && len(recent) > cp.MaxRecent {
+		recent = recent[:cp.MaxRecent]
+	}
+	cp.Recent = recent
+}
+
+func (cp *ColorPicker) setHSVA(h, s, v, a float64) {
+	if h == cp.h && s == cp.s && v == cp.v && a == cp.a {
+		return
+	}
+	cp.h, cp.s, cp.v, cp.a = h, s, v, a
+	cp.updateText()
+	if cp.OnColorChanged != nil {
+		cp.OnColorChanged(cp.Color())
+	}
+}
+
+// Show color in line edit unless it's being edited.
+func (cp *ColorPicker) updateText() {
+	if cp.Edit.Equals(cp.Surface.Focus()) {
+		return
+	}
+	cp.Edit.Text = ColorHex(cp.Color())
+	cp.Edit.CursorPos = 0
+	cp.Edit.TextOffset = 0
+}
+
+func (cp *ColorPicker) onEditingFinished() {
+	c, err := ParseColor(cp.Edit.Text)
+	cp.Surface.SetFocus(nil)
+	if err != nil {
+		cp.updateText()
+		return
+	}
+	h, s, v, a := ColorToHSVA(c)
+	if s == 0 || v == 0 {
+		h = cp.h
+	}
+	cp.setHSVA(h, s, v, a)
+	cp.updateText()
+	cp.AddRecent(cp.Color())
+}
+
+// Compute part rectangles and place line edit.
+func (cp *ColorPicker) layout() pickerLayout {
+	theme := cp.MyTheme()
+	pad := theme.Pad
+	lh := math.Floor(cp.Surface.GetTextRect("Wg", theme.TitleFont).H() + pad*2)
+	w, h := cp.Rect.W(), cp.Rect.H()
+	min := cp.GlobalRect().Min
+	var l pickerLayout
+
+	// Bottom row: line edit and preview swatch.
+	cp.Edit.Rect = R(pad, pad, w-pad*2-lh*2, pad+lh)
+	l.preview = R(w-pad-lh*2, pad, w-pad, pad+lh).Moved(min)
+
+	// Recent colors row.
+	y := pad*2 + lh
+	step := lh + pad/2
+	for x := pad; x+lh <= w-pad && len(l.recent) < len(cp.Recent); x += step {
+		l.recent = append(l.recent, R(x, y, x+lh, y+lh).Moved(min))
+	}
+
+	// Square and strips take the rest.
+	y += lh + pad
+	sw := math.Floor(lh * 0.75)
+	x := w - pad - sw
+	l.alpha = R(x, y, x+sw, h-pad).Moved(min)
+	x -= pad + sw
+	l.hue = R(x, y, x+sw, h-pad).Moved(min)
+	l.sv = R(pad, y, x-pad, h-pad).Moved(min)
+	return l
+}
+
+func (cp *ColorPicker) onMouseDown(bt Button) {
+	if bt != MouseButtonLeft || cp.IsDisabled() {
+		return
+	}
+	pos := cp.Surface.MousePos()
+	l := cp.layout()
+	switch {
+	case l.sv.Contains(pos):
+		cp.drag = pickerSV
+	case l.hue.Contains(pos):
+		cp.drag = pickerHue
+	case l.alpha.Contains(pos):
+		cp.drag = pickerAlpha
+	default:
+		for i, r := range l.recent {
+			if r.Contains(pos) {
+				c := cp.Recent[i]
+				cp.SetColor(c)
+				if cp.OnColorChanged != nil {
+					cp.OnColorChanged(cp.Color())
+				}
+				cp.AddRecent(c)
+				return
+			}
+		}
+		return
+	}
+	cp.dragTo(pos, l)
+}
+
+// Returns fraction of position within rect from 0 to 1
+// horizontally and from top (0) to bottom (1) vertically.
+func pickerFraction(pos Vec, r Rect) (fx, fy float64) {
+	clamp := func(v float64) float64 {
+		return math.Max(0, math.Min(1, v))
+	}
+	return clamp((pos.X - r.Min.X) / r.W()), clamp((r.Max.Y - pos.Y) / r.H())
+}
+
+func (cp *ColorPicker) dragTo(pos Vec, l pickerLayout) {
+	switch cp.drag {
+	case pickerSV:
+		fx, fy := pickerFraction(pos, l.sv)
+		cp.setHSVA(cp.h, fx, 1-fy, cp.a)
+	case pickerHue:
+		_, fy := pickerFraction(pos, l.hue)
+		cp.setHSVA(fy*360, cp.s, cp.v, cp.a)
+	case pickerAlpha:
+		_, fy := pickerFraction(pos, l.alpha)
+		cp.setHSVA(cp.h, cp.s, cp.v, 1-fy)
+	}
+}
+
+// ProcessMouse generates mouse events. Besides Panel
+// processing, it tracks dragging even if pointer leaves picker.
+func (cp *ColorPicker) ProcessMouse(wu Widget) {
+	if cp.drag != pickerNone {
+		if cp.Surface.Pressed(MouseButtonLeft) {
+			cp.dragTo(cp.Surface.MousePos(), cp.layout())
+		} else {
+			cp.drag = pickerNone
+			cp.AddRecent(cp.Color())
+		}
+	}
+	cp.Panel.ProcessMouse(wu)
+}
+
+// Draw checkerboard showing translucency of colors drawn over it.
+func (cp *ColorPicker) drawChecker(r Rect) {
+	cp.Surface.DrawFillRect(r, RGB(1, 1, 1))
+	dark := RGB(0.75, 0.75, 0.75)
+	for y, row := r.Min.Y, 0; y < r.Max.Y; y, row = y+pickerCheckerSize, row+1 {
+		for x, col := r.Min.X, 0; x < r.Max.X; x, col = x+pickerCheckerSize, col+1 {
+			if (row+col)%2 == 1 {
+				cell := R(x, y, math.Min(x+pickerCheckerSize, r.Max.X), math.Min(y+pickerCheckerSize, r.Max.Y))
+				cp.Surface.DrawFillRect(cell, dark)
+			}
+		}
+	}
+}
+
+// Paint draws the widget without children.
+func (cp *ColorPicker) Paint() {
+	cp.Panel.Paint()
+	l := cp.layout()
+	s := cp.Surface
+	theme := cp.MyTheme()
+	frame, _ := theme.Drawers[ThemeColorPickerFrame]
+	marker, _ := theme.Drawers[ThemeColorPickerMarker]
+	drawFrame := func(r Rect) {
+		if frame != nil {
+			frame.Draw(s, r, cp.Extras...)
+		}
+	}
+	drawMarker := func(r Rect) {
+		if marker != nil {
+			marker.Draw(s, r, cp.Extras...)
+		}
+	}
+	transparent := color.NRGBA{}
+
+	// Saturation grows to the right, value grows to the top.
+	s.DrawGradientRect(l.sv, RGB(1, 1, 1), HSVA(cp.h, 1, 1, 1), Horizontal)
+	s.DrawGradientRect(l.sv, transparent, RGB(0, 0, 0), Vertical)
+	drawFrame(l.sv)
+	p := V(l.sv.Min.X+cp.s*l.sv.W(), l.sv.Min.Y+cp.v*l.sv.H())
+	drawMarker(R(p.X-4, p.Y-4, p.X+4, p.Y+4))
+
+	// Hue goes from 0 at the top to 360 at the bottom.
+	seg := l.hue.H() / 6
+	for i := 0; i < 6; i++ {
+		top := l.hue.Max.Y - seg*float64(i)
+		r := R(l.hue.Min.X, top-seg, l.hue.Max.X, top)
+		s.DrawGradientRect(r, HSVA(float64(i)*60, 1, 1, 1), HSVA(float64(i+1)*60, 1, 1, 1), Vertical)
+	}
+	drawFrame(l.hue)
+	y := l.hue.Max.Y - cp.h/360*l.hue.H()
+	drawMarker(R(l.hue.Min.X-2, y-2, l.hue.Max.X+2, y+2))
+
+	// Alpha goes from opaque at the top to transparent at the bottom.
+	cp.drawChecker(l.alpha)
+	s.DrawGradientRect(l.alpha, HSVA(cp.h, cp.s, cp.v, 1), transparent, Vertical)
+	drawFrame(l.alpha)
+	y = l.alpha.Min.Y + cp.a*l.alpha.H()
+	drawMarker(R(l.alpha.Min.X-2, y-2, l.alpha.Max.X+2, y+2))
+
+	cp.drawChecker(l.preview)
+	s.DrawFillRect(l.preview, cp.Color())
+	drawFrame(l.preview)
+
+	for i, r := range l.recent {
+		cp.drawChecker(r)
+		s.DrawFillRect(r, cp.Recent[i])
+		drawFrame(r)
+	}
+}
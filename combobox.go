@@ -0,0 +1,218 @@
+package grue
+
+// ComboBox is a widget showing current choice
+// and opening popup menu with all options when clicked.
+// If editable, text can be typed in addition to choosing
+// one of the options.
+type ComboBox struct {
+	*Panel
+	// Items are options to choose from.
+	// Item Handler (if set) is called after selection,
+	// its result is ignored.
+	Items []MenuOption
+	// Current is index of selected item, -1 if none.
+	Current int
+	// MaxVisible is maximum number of items shown
+	// in popup at once. Popup is scrollable if there
+	// are more items.
+	MaxVisible int
+	// Edit is line edit of editable combo box (nil otherwise).
+	Edit *LineEdit
+
+	OnSelected func(index int, id string)
+
+	popup *PopupMenu
+}
+
+// NewComboBox creates new combo box.
+func NewComboBox(parent Widget, b Base, items ...MenuOption) *ComboBox {
+	cb := &ComboBox{
+		Panel:      NewPanel(nil, b),
+		Items:      items,
+		Current:    -1,
+		MaxVisible: 8,
+	}
+	InitWidget(parent, cb)
+	cb.FocusPolicy = FocusStrong
+
+	// Popup is opened on click (not on mouse down),
+	// otherwise following release outside popup closes it.
+	cb.OnMouseClick = func(bt Button) {
+		if bt != MouseButtonLeft {
+			return
+		}
+		cb.Open()
+	}
+	cb.OnKeys = cb.onKeys
+	return cb
+}
+
+// SetEditable turns editable mode on or off.
+// In editable mode text is entered in LineEdit
+// (accessible as Edit field), and popup is opened
+// by clicking the arrow.
+func (cb *ComboBox) SetEditable(on bool) {
+	if !on {
+		if cb.Edit != nil {
+			cb.Edit.Close()
+			cb.Edit = nil
+		}
+		return
+	}
+	if cb.Edit != nil {
+		return
+	}
+	cb.Edit = NewLineEdit(cb, Base{
+		Rect:            R0(cb.Rect.W()-cb.Rect.H(), cb.Rect.H()),
+		PlaceholderText: cb.PlaceholderText,
+		Disabled:        cb.Disabled,
+	})
+	if cb.Current >= 0 && cb.Current < len(cb.Items) {
+		cb.Edit.Text = cb.Items[cb.Current].Text
+	}
+	cb.Edit.OnEditingFinished = func() {
+		cb.Select(cb.Find(cb.Edit.Text))
+	}
+}
+
+// Find returns index of the first item having given text,
+// -1 if there is none.
+func (cb *ComboBox) Find(text string) int {
+	for i, it := range cb.Items {
+		if it.Text == text {
+			return i
+		}
+	}
+	return -1
+}
+
+// CurrentID returns ID of selected item,
+// empty string if nothing is selected.
+func (cb *ComboBox) CurrentID() string {
+	if cb.Current < 0 || cb.Current >= len(cb.Items) {
+		return ""
+	}
+	return cb.Items[cb.Current].ID
+}
+
+// Select makes item with given index current and
+// calls OnSelected. Pass -1 to clear selection.
+func (cb *ComboBox) Select(i int) {
+	if i < -1 || i >= len(cb.Items) {
+		return
+	}
+	cb.Current = i
+	if cb.Edit != nil && i >= 0 {
+		cb.Edit.Text = cb.Items[i].Text
+		cb.Edit.CursorPos = len(cb.Edit.Text)
+		cb.Edit.TextOffset = 0
+	}
+	if cb.OnSelected != nil {
+		cb.OnSelected(i, cb.CurrentID())
+	}
+}
+
+// IsOpen returns true if popup with items is shown.
+func (cb *ComboBox) IsOpen() bool {
+	return cb.popup != nil && cb.Surface.IsPopUp(cb.popup)
+}
+
+// Open shows popup with items below the combo box.
+func (cb *ComboBox) Open() {
+	if cb.IsDisabled() || len(cb.Items) == 0 || cb.IsOpen() {
+		return
+	}
+	opts := make([]MenuOption, len(cb.Items))
+	for i, it := range cb.Items {
+		i, handler := i, it.Handler
+		it.Handler = func(pm *PopupMenu) bool {
+			cb.Surface.SetFocus(cb.Virt)
+			cb.Select(i)
+			if handler != nil {
+				handler(pm)
+			}
+			return true
+		}
+		opts[i] = it
+	}
+	r := cb.GlobalRect()
+	cb.popup = NewScrollPopupMenu(cb.Surface.Root(), Base{
+		Rect: R(r.Min.X, r.Min.Y-r.H(), r.Max.X, r.Min.Y),
+	}, cb.MaxVisible, opts...)
+	cb.popup.PlaceAt(r, SideBelow)
+	cb.popup.SetCurrent(cb.Current)
+	cb.Surface.SetFocus(cb.popup)
+}
+
+// Paint draws the widget without children.
+func (cb *ComboBox) Paint() {
+	r := cb.GlobalRect()
+	theme := cb.MyTheme()
+	tdef, _ := theme.Drawers[ThemeComboBox]
+	var tcur ThemeDrawer
+	tcol := theme.ButtonTextColor
+	if tcol == nil {
+		tcol = theme.TextColor
+	}
+	switch {
+	case cb.IsDisabled():
+		tcur, _ = theme.Drawers[ThemeComboBoxDisabled]
+		tcol = theme.DisabledTextColor
+	case cb.IsOpen():
+		tcur, _ = theme.Drawers[ThemeComboBoxActive]
+	case cb.PointerInside:
+		tcur, _ = theme.Drawers[ThemeComboBoxHL]
+	}
+	if tcur != nil {
+		tdef = tcur
+	}
+	if tdef != nil {
+		tdef.Draw(cb.Surface, r, cb.Extras...)
+	}
+	ar := R(r.Max.X-r.H(), r.Min.Y, r.Max.X, r.Max.Y)
+	if tar, _ := theme.Drawers[ThemeComboBoxArrow]; tar != nil {
+		tar.Draw(cb.Surface, ar, cb.Extras...)
+	}
+	if cb.Edit != nil {
+		return
+	}
+	tr := r.Extended(0, 0, -ar.W(), 0)
+	if cb.Current >= 0 && cb.Current < len(cb.Items) {
+		it := cb.Items[cb.Current]
+		cb.DrawImageAndTextIn(tr, it.Image, it.Text, tcol, cb.ImageAlign, cb.TextAlign, Vec{})
+	} else if cb.PlaceholderText != "" {
+		tcol = theme.PlaceholderColor
+		if tcol == nil {
+			tcol = theme.TextColor
+		}
+		cb.DrawImageAndTextIn(tr, "", cb.PlaceholderText, tcol, 0, cb.TextAlign, Vec{})
+	}
+}
+
+// Select next or previous enabled item.
+func (cb *ComboBox) step(delta int) {
+	for i := cb.Current + delta; i >= 0 && i < len(cb.Items); i += delta {
+		if !cb.Items[i].Disabled {
+			cb.Select(i)
+			return
+		}
+	}
+}
+
+func (cb *ComboBox) onKeys() bool {
+	if cb.IsDisabled() || !cb.Equals(cb.Surface.Focus()) {
+		return false
+	}
+	s := cb.Surface
+	switch {
+	case s.JustPressed(KeyDown) || s.Repeated(KeyDown):
+		cb.step(1)
+	case s.JustPressed(KeyUp) || s.Repeated(KeyUp):
+		cb.step(-1)
+	case s.JustPressed(KeyEnter) || s.JustPressed(KeySpace):
+		cb.Open()
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,60 @@
+package grue
+
+// Returns context menu options of the nearest widget
+// (starting from w and up to parents) having them.
+func contextMenu(w Widget) (Widget, []MenuOption) {
+	for ; w != nil; w = w.GetPanel().Parent {
+		p := w.GetPanel()
+		if p.IsDisabled() {
+			continue
+		}
+		if p.OnContextMenu != nil {
+			return w, p.OnContextMenu()
+		}
+		if p.ContextMenu != nil {
+			return w, p.ContextMenu
+		}
+	}
+	return nil, nil
+}
+
+// ShowContextMenu opens context menu of the widget (or its
+// nearest parent having one) with top left corner at pos
+// (screen coords). Menu is kept inside of the surface
+// (it's shown above pos if there is no space below).
+// Options texts may have mnemonics (see PopupMenu.Mnemonics).
+// Returns nil if there is no menu to show.
+func ShowContextMenu(w Widget, pos Vec) *PopupMenu {
+	w, opts := contextMenu(w)
+	if len(opts) == 0 {
+		return nil
+	}
+	p := w.GetPanel()
+	s := p.Surface
+	s.PopDownTo(nil)
+	theme := p.MyTheme()
+	step := menuStep(s, theme)
+	pm := NewPopupMenu(s.Root(), Base{
+		Theme: p.Theme,
+		Rect:  R(pos.X, pos.Y-step, pos.X+menuWidth(s, theme, step, opts, true), pos.Y),
+	}, opts...)
+	pm.Mnemonics = true
+	s.SetFocus(pm)
+	return pm
+}
+
+// ProcessContextMenuKey opens context menu of widget w if Menu
+// key is pressed. Menu is shown at pointer if it's inside of the
+// widget, or at the widget center otherwise.
+// Backends call it before keyboard processing with focused widget
+// (or widget under pointer if there is no focus).
+func ProcessContextMenuKey(s Surface, w Widget) bool {
+	if w == nil || !s.JustPressed(KeyMenu) {
+		return false
+	}
+	pos := s.MousePos()
+	if r := w.GlobalRect(); !r.Contains(pos) {
+		pos = r.Center()
+	}
+	return ShowContextMenu(w, pos) != nil
+}
@@ -0,0 +1,224 @@
+package grue
+
+import "math"
+
+// Dialog is modal window with title bar, content area
+// and a row of buttons. While dialog is open, widgets
+// beneath it don't receive input.
+// Dialog is closed when any of its buttons is pressed.
+type Dialog struct {
+	*Panel
+	// Content is a panel to put dialog widgets to.
+	Content *Panel
+	Buttons []*PushButton
+	// DefaultButton is index of button pressed with Enter,
+	// CancelButton -- with Escape. -1 if none.
+	DefaultButton int
+	CancelButton  int
+
+	// OnButton is called with index of pressed button
+	// after dialog is closed.
+	OnButton func(index int)
+}
+
+// NewDialog creates new modal dialog with given button
+// titles. Text of Base is used as title.
+// Use Surface.Root() as parent to have the whole surface dimmed.
+func NewDialog(parent Widget, b Base, buttons ...string) *Dialog {
+	dlg := &Dialog{
+		Panel:         NewPanel(nil, b),
+		DefaultButton: 0,
+		CancelButton:  len(buttons) - 1,
+	}
+	InitWidget(parent, dlg)
+
+	theme := dlg.MyTheme()
+	pad := theme.Pad
+	barH := dlg.barHeight()
+	w, h := dlg.Rect.W(), dlg.Rect.H()
+	dlg.Content = NewPanel(dlg, Base{
+		Rect: R(pad, barH+pad*2, w-pad, h-barH-pad),
+	})
+
+	x := w - pad
+	for i := len(buttons) - 1; i >= 0; i-- {
+		bw := math.Max(80, dlg.Surface.GetTextRect(buttons[i], theme.TitleFont).W()+pad*4)
+		bt := NewPushButton(dlg, Base{
+			Rect: R(x-bw, pad, x, pad+barH),
+			Text: buttons[i],
+		})
+		x -= bw + pad
+		i := i
+		bt.OnPress = func() {
+			dlg.Press(i)
+		}
+		dlg.Buttons = append([]*PushButton{bt}, dlg.Buttons...)
+	}
+	dlg.OnKeys = dlg.onKeys
+
+	dlg.Surface.BeginModal(dlg)
+	dlg.Surface.SetFocus(dlg)
+	return dlg
+}
+
+// Press closes dialog and calls OnButton with button index.
+func (dlg *Dialog) Press(i int) {
+	dlg.Close()
+	if dlg.OnButton != nil {
+		dlg.OnButton(i)
+	}
+}
+
+// Close dialog and its children.
+func (dlg *Dialog) Close() {
+	dlg.Surface.EndModal(dlg)
+	dlg.Panel.Close()
+}
+
+// Center places dialog at the center of its parent.
+func (dlg *Dialog) Center() {
+	if dlg.Parent == nil {
+		return
+	}
+	pr := dlg.Parent.GetPanel().Rect
+	dlg.Place(V(math.Floor((pr.W()-dlg.Rect.W())/2), math.Floor((pr.H()-dlg.Rect.H())/2)))
+}
+
+func (dlg *Dialog) barHeight() float64 {
+	theme := dlg.MyTheme()
+	return dlg.Surface.GetTextRect("Wg", theme.TitleFont).H() + theme.Pad*2
+}
+
+func (dlg *Dialog) onKeys() bool {
+	if !dlg.Equals(dlg.Surface.Modal()) {
+		return false
+	}
+	s := dlg.Surface
+	switch {
+	case s.JustPressed(KeyEnter) || s.JustPressed(KeyKPEnter):
+		if dlg.DefaultButton < 0 || dlg.DefaultButton >= len(dlg.Buttons) {
+			return false
+		}
+		dlg.Press(dlg.DefaultButton)
+	case s.JustPressed(KeyEscape):
+		if dlg.CancelButton < 0 || dlg.CancelButton >= len(dlg.Buttons) {
+			return false
+		}
+		dlg.Press(dlg.CancelButton)
+	default:
+		return false
+	}
+	return true
+}
+
+// Paint draws the widget without children.
+func (dlg *Dialog) Paint() {
+	theme := dlg.MyTheme()
+	if dlg.Equals(dlg.Surface.Modal()) {
+		if td, _ := theme.Drawers[ThemeDialogDim]; td != nil {
+			td.Draw(dlg.Surface, dlg.Surface.Root().GlobalRect())
+		}
+	}
+	r := dlg.GlobalRect()
+	if td, _ := theme.Drawers[ThemeDialog]; td != nil {
+		td.Draw(dlg.Surface, r, dlg.Extras...)
+	}
+	tr := R(r.Min.X, r.Max.Y-dlg.barHeight(), r.Max.X, r.Max.Y)
+	if td, _ := theme.Drawers[ThemeDialogTitle]; td != nil {
+		td.Draw(dlg.Surface, tr, dlg.Extras...)
+	}
+	tcol := theme.ButtonTextColor
+	if tcol == nil {
+		tcol = theme.TextColor
+	}
+	dlg.DrawImageAndTextIn(tr, dlg.Image, dlg.Text, tcol, dlg.ImageAlign, dlg.TextAlign, Vec{})
+}
+
+// DialogButton is standard button of message box.
+type DialogButton int
+
+// Standard buttons.
+const (
+	ButtonOK DialogButton = iota
+	ButtonCancel
+	ButtonYes
+	ButtonNo
+)
+
+func (db DialogButton) String() string {
+	switch db {
+	case ButtonOK:
+		return "OK"
+	case ButtonCancel:
+		return "Cancel"
+	case ButtonYes:
+		return "Yes"
+	case ButtonNo:
+		return "No"
+	}
+	return ""
+}
+
+// MessageBox shows modal dialog with text and given buttons
+// at the center of surface. Callback (if not nil) receives
+// pressed button. Enter presses the first button, Escape --
+// Cancel, No or the only button, whichever is present.
+func MessageBox(s Surface, title, text string, cb func(DialogButton), buttons ...DialogButton) *Dialog {
+	theme := s.GetTheme()
+	pad := theme.Pad
+	names := make([]string, len(buttons))
+	cancel := -1
+	btW := 0.0
+	for i, b := range buttons {
+		names[i] = b.String()
+		btW += math.Max(80, s.GetTextRect(names[i], theme.TitleFont).W()+pad*4) + pad
+		if b == ButtonCancel || (b == ButtonNo && cancel < 0) {
+			cancel = i
+		}
+	}
+	if cancel < 0 && len(buttons) == 1 {
+		cancel = 0
+	}
+	lineH := s.GetTextRect("Wg", theme.TitleFont).H() + pad*2
+	textW := math.Max(s.GetTextRect(text, theme.TitleFont).W(), s.GetTextRect(title, theme.TitleFont).W())
+	w := math.Ceil(math.Max(textW+pad*6, btW+pad))
+	h := lineH*3 + pad*5
+
+	dlg := NewDialog(s.Root(), Base{
+		Rect: R0(w, h),
+		Text: title,
+	}, names...)
+	dlg.Center()
+	dlg.Content.Text = text
+	dlg.CancelButton = cancel
+	dlg.OnButton = func(i int) {
+		if cb != nil {
+			cb(buttons[i])
+		}
+	}
+	return dlg
+}
+
+// InfoBox shows message box with OK button.
+func InfoBox(s Surface, title, text string, cb func()) *Dialog {
+	return MessageBox(s, title, text, func(DialogButton) {
+		if cb != nil {
+			cb()
+		}
+	}, ButtonOK)
+}
+
+// ConfirmBox shows message box with OK and Cancel buttons.
+// Callback receives true if OK is pressed.
+func ConfirmBox(s Surface, title, text string, cb func(ok bool)) *Dialog {
+	return MessageBox(s, title, text, func(b DialogButton) {
+		if cb != nil {
+			cb(b == ButtonOK)
+		}
+	}, ButtonOK, ButtonCancel)
+}
+
+// QuestionBox shows message box with Yes, No and Cancel buttons.
+func QuestionBox(s Surface, title, text string, cb func(DialogButton)) *Dialog {
+	return MessageBox(s, title, text, cb, ButtonYes, ButtonNo, ButtonCancel)
+}
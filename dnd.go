@@ -0,0 +1,139 @@
+package grue
+
+// DragData describes object being dragged.
+type DragData struct {
+	// Kind is MIME-like type of payload, e.g. "item/weapon".
+	// Targets accept or reject drag by it.
+	Kind    string
+	Payload interface{}
+
+	// Drag visual: sprite drawn centered at pointer,
+	// and/or widget drawn centered at pointer (snapshot).
+	Image    string
+	Snapshot Widget
+
+	// Source is widget that started dragging.
+	Source Widget
+	// OnEnd is called when drag is finished,
+	// dropped is false if drag was cancelled or rejected.
+	OnEnd func(dropped bool)
+
+	target   Widget
+	accepted bool
+}
+
+// Target returns widget under pointer that accepted drag (nil if none).
+func (d *DragData) Target() Widget {
+	if !d.accepted {
+		return nil
+	}
+	return d.target
+}
+
+// AcceptDrops returns OnDragEnter handler accepting given kinds.
+func AcceptDrops(kinds ...string) func(d *DragData) bool {
+	return func(d *DragData) bool {
+		for _, k := range kinds {
+			if k == d.Kind {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Cancel notifies target and source that drag is cancelled.
+// Backends call it from Surface.CancelDrag.
+func (d *DragData) Cancel() {
+	d.leave()
+	if d.OnEnd != nil {
+		d.OnEnd(false)
+	}
+}
+
+// Draw drag visual at pointer position.
+func (d *DragData) Draw(s Surface) {
+	pos := s.MousePos()
+	if d.Snapshot != nil {
+		p := d.Snapshot.GetPanel()
+		saved := p.Rect
+		p.Rect = p.Rect.Moved(pos.Sub(p.GlobalRect().Center()))
+		d.Snapshot.Render()
+		p.Rect = saved
+	}
+	if d.Image != "" {
+		s.DrawImage(d.Image, pos, nil)
+	}
+}
+
+func (d *DragData) leave() {
+	if d.accepted && d.target.GetPanel().OnDragLeave != nil {
+		d.target.GetPanel().OnDragLeave(d)
+	}
+	d.target = nil
+	d.accepted = false
+}
+
+// Returns nearest widget (starting from w and up to parents)
+// that handles drag and drop.
+func dropTarget(w Widget) Widget {
+	for w != nil {
+		if w.GetPanel().OnDragEnter != nil {
+			return w
+		}
+		w = w.GetPanel().Parent
+	}
+	return nil
+}
+
+// ProcessDrag generates drag and drop events for active drag.
+// wu holds top widget under mouse.
+// Backends call it instead of mouse and keyboard processing
+// while drag is active.
+func ProcessDrag(s Surface, wu Widget) {
+	d := s.Drag()
+	if d == nil {
+		return
+	}
+	if s.JustPressed(KeyEscape) {
+		s.CancelDrag()
+		return
+	}
+	t := dropTarget(wu)
+	switch {
+	case t == nil && d.target != nil,
+		t != nil && !t.Equals(d.target):
+		d.leave()
+		d.target = t
+		d.accepted = t != nil && t.GetPanel().OnDragEnter(d)
+	case d.accepted && t.GetPanel().OnDragOver != nil:
+		t.GetPanel().OnDragOver(d)
+	}
+	if !s.JustReleased(MouseButtonLeft) {
+		return
+	}
+	if !d.accepted {
+		s.CancelDrag()
+		return
+	}
+	if t.GetPanel().OnDrop != nil {
+		t.GetPanel().OnDrop(d)
+	}
+	if d.OnEnd != nil {
+		d.OnEnd(true)
+	}
+	s.EndDrag()
+}
+
+// DropFiles delivers files dropped from OS to the nearest widget
+// (starting from wu and up to parents) having OnFilesDropped handler.
+// Returns false if there is no such widget.
+func DropFiles(wu Widget, paths []string, pos Vec) bool {
+	for w := wu; w != nil; w = w.GetPanel().Parent {
+		if h := w.GetPanel().OnFilesDropped; h != nil {
+			h(paths, pos)
+			return true
+		}
+	}
+	return false
+}
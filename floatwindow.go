@@ -0,0 +1,292 @@
+package grue
+
+import "math"
+
+// Edges of floating window being dragged.
+const (
+	edgeLeft = 1 << iota
+	edgeRight
+	edgeBottom
+	edgeTop
+	// Whole window is moved.
+	edgeAll = edgeLeft | edgeRight | edgeBottom | edgeTop
+)
+
+// Width of window border that can be dragged to resize (pixels).
+const windowBorder = 4
+
+// FloatingWindow is a window inside of parent widget,
+// which can be moved by its title bar and resized by
+// its edges. Clicking window raises it above siblings.
+// Window always stays within parent bounds.
+type FloatingWindow struct {
+	*Panel
+	// Content is a panel to put window widgets to.
+	Content *Panel
+
+	Closable    bool
+	Minimizable bool
+	Resizable   bool
+	Minimized   bool
+	// MinSize limits resizing.
+	MinSize Vec
+
+	// OnClose is called when close button is pressed.
+	// Window is closed unless it returns false.
+	OnClose    func() bool
+	OnMinimize func(minimized bool)
+
+	dragEdges int
+	dragPos   Vec
+	dragRect  Rect
+	// Height before minimizing.
+	restoreH float64
+}
+
+// NewFloatingWindow creates new floating window.
+// Text of Base is used as title.
+func NewFloatingWindow(parent Widget, b Base) *FloatingWindow {
+	fw := &FloatingWindow{
+		Panel:       NewPanel(nil, b),
+		Closable:    true,
+		Minimizable: true,
+		Resizable:   true,
+	}
+	InitWidget(parent, fw)
+	fw.MinSize = V(fw.barHeight()*4, fw.barHeight()*2)
+	fw.Content = NewPanel(fw, Base{})
+	fw.layout()
+
+	fw.OnMouseDown = func(bt Button) {
+		if bt != MouseButtonLeft {
+			return
+		}
+		pos := fw.Surface.MousePos()
+		if fw.closeRect().Contains(pos) || fw.minimizeRect().Contains(pos) {
+			return
+		}
+		fw.dragEdges = fw.edgesAt(pos)
+		fw.dragPos = pos
+		fw.dragRect = fw.Rect
+	}
+	fw.OnMouseUp = func(bt Button) {
+		if bt == MouseButtonLeft {
+			fw.dragEdges = 0
+		}
+	}
+	fw.OnMouseClick = func(bt Button) {
+		if bt != MouseButtonLeft {
+			return
+		}
+		pos := fw.Surface.MousePos()
+		switch {
+		case fw.closeRect().Contains(pos):
+			if fw.OnClose == nil || fw.OnClose() {
+				fw.Close()
+			}
+		case fw.minimizeRect().Contains(pos):
+			fw.SetMinimized(!fw.Minimized)
+		}
+	}
+	return fw
+}
+
+// SetMinimized collapses window to title bar or restores it.
+func (fw *FloatingWindow) SetMinimized(minimized bool) {
+	if fw.Minimized == minimized {
+		return
+	}
+	fw.Minimized = minimized
+	if minimized {
+		fw.restoreH = fw.Rect.H()
+		fw.Rect.Min.Y = fw.Rect.Max.Y - fw.barHeight()
+		fw.Content.SetHidden(true)
+	} else {
+		fw.Rect.Min.Y = fw.Rect.Max.Y - fw.restoreH
+		fw.Content.SetHidden(false)
+		fw.clamp()
+		fw.layout()
+	}
+	if fw.OnMinimize != nil {
+		fw.OnMinimize(minimized)
+	}
+}
+
+// IsActive returns true if window is above all its siblings.
+func (fw *FloatingWindow) IsActive() bool {
+	if fw.Parent == nil {
+		return false
+	}
+	ch := fw.Parent.GetPanel().Children
+	return len(ch) > 0 && fw.Equals(ch[len(ch)-1])
+}
+
+// ProcessMouse generates mouse events. Besides Panel
+// processing, it raises window on click and tracks
+// dragging even if pointer leaves the window.
+func (fw *FloatingWindow) ProcessMouse(wu Widget) {
+	s := fw.Surface
+	if fw.dragEdges != 0 {
+		if s.Pressed(MouseButtonLeft) {
+			fw.dragTo(s.MousePos())
+		} else {
+			fw.dragEdges = 0
+		}
+	}
+	if wu != nil && fw.IsAncestorOf(wu) &&
+		(s.JustPressed(MouseButtonLeft) || s.JustPressed(MouseButtonRight) ||
+			s.JustPressed(MouseButtonMiddle)) {
+		fw.Raise()
+	}
+	fw.Panel.ProcessMouse(wu)
+}
+
+// Returns edges of window under pointer, edgeAll for title bar.
+func (fw *FloatingWindow) edgesAt(pos Vec) int {
+	r := fw.GlobalRect()
+	edges := 0
+	if fw.Resizable && !fw.Minimized {
+		if pos.X <= r.Min.X+windowBorder {
+			edges |= edgeLeft
+		}
+		if pos.X >= r.Max.X-windowBorder {
+			edges |= edgeRight
+		}
+		if pos.Y <= r.Min.Y+windowBorder {
+			edges |= edgeBottom
+		}
+		if pos.Y >= r.Max.Y-windowBorder {
+			edges |= edgeTop
+		}
+	}
+	if edges == 0 && pos.Y >= r.Max.Y-fw.barHeight() {
+		edges = edgeAll
+	}
+	return edges
+}
+
+func (fw *FloatingWindow) dragTo(pos Vec) {
+	d := pos.Sub(fw.dragPos)
+	r := fw.dragRect
+	if fw.dragEdges == edgeAll {
+		fw.Rect = r.Moved(d)
+		fw.clamp()
+		return
+	}
+	if fw.dragEdges&edgeLeft != 0 {
+		r.Min.X = math.Min(r.Min.X+d.X, r.Max.X-fw.MinSize.X)
+	}
+	if fw.dragEdges&edgeRight != 0 {
+		r.Max.X = math.Max(r.Max.X+d.X, r.Min.X+fw.MinSize.X)
+	}
+	if fw.dragEdges&edgeBottom != 0 {
+		r.Min.Y = math.Min(r.Min.Y+d.Y, r.Max.Y-fw.MinSize.Y)
+	}
+	if fw.dragEdges&edgeTop != 0 {
+		r.Max.Y = math.Max(r.Max.Y+d.Y, r.Min.Y+fw.MinSize.Y)
+	}
+	if fw.Parent != nil {
+		pr := fw.Parent.GetPanel().Rect
+		r.Min.X = math.Max(r.Min.X, 0)
+		r.Min.Y = math.Max(r.Min.Y, 0)
+		r.Max.X = math.Min(r.Max.X, pr.W())
+		r.Max.Y = math.Min(r.Max.Y, pr.H())
+	}
+	fw.Rect = r
+	fw.layout()
+}
+
+// Keep window within parent bounds.
+func (fw *FloatingWindow) clamp() {
+	if fw.Parent == nil {
+		return
+	}
+	pr := fw.Parent.GetPanel().Rect
+	d := Vec{}
+	if fw.Rect.Max.X > pr.W() {
+		d.X = pr.W() - fw.Rect.Max.X
+	}
+	if fw.Rect.Min.X+d.X < 0 {
+		d.X = -fw.Rect.Min.X
+	}
+	if fw.Rect.Max.Y > pr.H() {
+		d.Y = pr.H() - fw.Rect.Max.Y
+	}
+	if fw.Rect.Min.Y+d.Y < 0 {
+		d.Y = -fw.Rect.Min.Y
+	}
+	fw.Rect = fw.Rect.Moved(d)
+}
+
+// Fit content panel to window size.
+func (fw *FloatingWindow) layout() {
+	pad := fw.MyTheme().Pad
+	fw.Content.Rect = R(pad, pad, fw.Rect.W()-pad, fw.Rect.H()-fw.barHeight())
+}
+
+func (fw *FloatingWindow) barHeight() float64 {
+	theme := fw.MyTheme()
+	return fw.Surface.GetTextRect("Wg", theme.TitleFont).H() + theme.Pad*2
+}
+
+func (fw *FloatingWindow) titleRect() Rect {
+	r := fw.GlobalRect()
+	return R(r.Min.X, r.Max.Y-fw.barHeight(), r.Max.X, r.Max.Y)
+}
+
+func (fw *FloatingWindow) closeRect() Rect {
+	if !fw.Closable {
+		return Rect{}
+	}
+	tr := fw.titleRect()
+	return R(tr.Max.X-tr.H(), tr.Min.Y, tr.Max.X, tr.Max.Y)
+}
+
+func (fw *FloatingWindow) minimizeRect() Rect {
+	if !fw.Minimizable {
+		return Rect{}
+	}
+	tr := fw.titleRect()
+	if fw.Closable {
+		tr.Max.X -= tr.H()
+	}
+	return R(tr.Max.X-tr.H(), tr.Min.Y, tr.Max.X, tr.Max.Y)
+}
+
+// Paint draws the widget without children.
+func (fw *FloatingWindow) Paint() {
+	theme := fw.MyTheme()
+	if td, _ := theme.Drawers[ThemeWindow]; td != nil {
+		td.Draw(fw.Surface, fw.GlobalRect(), fw.Extras...)
+	}
+	tr := fw.titleRect()
+	td, _ := theme.Drawers[ThemeWindowTitle]
+	if fw.IsActive() {
+		if tcur, _ := theme.Drawers[ThemeWindowTitleActive]; tcur != nil {
+			td = tcur
+		}
+	}
+	if td != nil {
+		td.Draw(fw.Surface, tr, fw.Extras...)
+	}
+	if fw.Closable {
+		if td, _ := theme.Drawers[ThemeWindowClose]; td != nil {
+			td.Draw(fw.Surface, fw.closeRect(), fw.Extras...)
+		}
+		tr.Max.X -= tr.H()
+	}
+	if fw.Minimizable {
+		if td, _ := theme.Drawers[ThemeWindowMinimize]; td != nil {
+			td.Draw(fw.Surface, fw.minimizeRect(), fw.Extras...)
+		}
+		tr.Max.X -= tr.H()
+	}
+	tcol := theme.ButtonTextColor
+	if tcol == nil {
+		tcol = theme.TextColor
+	}
+	if fw.IsDisabled() {
+		tcol = theme.DisabledTextColor
+	}
+	fw.DrawImageAndTextIn(tr, fw.Image, fw.Text, tcol, fw.ImageAlign, fw.TextAlign, Vec{})
+}
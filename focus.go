@@ -0,0 +1,108 @@
+package grue
+
+import "sort"
+
+// FocusPolicy defines how widget receives keyboard focus.
+type FocusPolicy int
+
+// Focus policies.
+const (
+	// FocusNone widget never gets focus.
+	FocusNone FocusPolicy = 0
+	// FocusClick widget gets focus by mouse press (default).
+	FocusClick FocusPolicy = 1
+	// FocusTab widget gets focus by Tab and Shift+Tab.
+	FocusTab FocusPolicy = 2
+	// FocusStrong widget gets focus both by click and Tab.
+	FocusStrong = FocusClick | FocusTab
+)
+
+// FocusChain returns widgets under root (including root)
+// that get focus by Tab, in order of their traversal.
+// Widgets with positive TabIndex go first in ascending
+// order, then the others in tree order. Hidden and
+// disabled subtrees are skipped.
+func FocusChain(root Widget) []Widget {
+	var chain []Widget
+	var walk func(w Widget)
+	walk = func(w Widget) {
+		p := w.GetPanel()
+		if p.Hidden || p.Disabled {
+			return
+		}
+		if p.FocusPolicy&FocusTab != 0 {
+			chain = append(chain, p.Virt)
+		}
+		for _, c := range p.Children {
+			walk(c)
+		}
+	}
+	if root.GetPanel().IsVisible() && !root.GetPanel().IsDisabled() {
+		walk(root)
+	}
+	sort.SliceStable(chain, func(i, j int) bool {
+		ti, tj := chain[i].GetPanel().TabIndex, chain[j].GetPanel().TabIndex
+		if ti <= 0 || tj <= 0 {
+			return ti > 0 && tj <= 0
+		}
+		return ti < tj
+	})
+	return chain
+}
+
+// FocusNext moves focus to the next (or previous if backward)
+// widget of the focus chain of root. Focus wraps around.
+// Returns false if chain is empty.
+func FocusNext(s Surface, root Widget, backward bool) bool {
+	chain := FocusChain(root)
+	if len(chain) == 0 {
+		return false
+	}
+	cur := -1
+	if f := s.Focus(); f != nil {
+		for i, w := range chain {
+			if w.Equals(f) {
+				cur = i
+				break
+			}
+		}
+	}
+	n := len(chain)
+	next := 0
+	switch {
+	case backward && cur < 0:
+		next = n - 1
+	case backward:
+		next = (cur - 1 + n) % n
+	case cur >= 0:
+		next = (cur + 1) % n
+	}
+	s.SetFocus(chain[next])
+	return true
+}
+
+// ProcessFocusKeys moves focus through the focus chain
+// of root by Tab and Shift+Tab (Ctrl+Tab is left for
+// widgets). Returns true if key is consumed.
+// Backends call it if focused widget didn't consume keys.
+func ProcessFocusKeys(s Surface, root Widget) bool {
+	if !s.JustPressed(KeyTab) && !s.Repeated(KeyTab) {
+		return false
+	}
+	ctrl, shift := modifiers(s)
+	if ctrl {
+		return false
+	}
+	return FocusNext(s, root, shift)
+}
+
+// Draw focus ring around widget if it has focus
+// and can get it by keyboard.
+func (p *Panel) drawFocusRing() {
+	if p.FocusPolicy&FocusTab == 0 || !p.Equals(p.Surface.Focus()) {
+		return
+	}
+	if td, _ := p.MyTheme().Drawers[ThemeFocusRing]; td != nil {
+		td.Draw(p.Surface, p.GlobalRect(), p.Extras...)
+	}
+}
@@ -0,0 +1,78 @@
+package grue
+
+import "testing"
+
+func TestFocusChain(t *testing.T) {
+	// Creates panel with given focus policy and tab index.
+	panel := func(parent Widget, fp FocusPolicy, ti int) *Panel {
+		p := NewPanel(parent, Base{})
+		p.FocusPolicy = fp
+		p.TabIndex = ti
+		return p
+	}
+
+	tests := []struct {
+		name  string
+		build func() (root Widget, want []Widget)
+	}{
+		{"tree order", func() (Widget, []Widget) {
+			root := panel(nil, FocusNone, 0)
+			a := panel(root, FocusTab, 0)
+			b := panel(root, FocusNone, 0)
+			b1 := panel(b, FocusStrong, 0)
+			c := panel(root, FocusTab, 0)
+			return root, []Widget{a, b1, c}
+		}},
+		{"click only is skipped", func() (Widget, []Widget) {
+			root := panel(nil, FocusNone, 0)
+			panel(root, FocusClick, 0)
+			b := panel(root, FocusTab, 0)
+			return root, []Widget{b}
+		}},
+		{"root itself", func() (Widget, []Widget) {
+			root := panel(nil, FocusTab, 0)
+			a := panel(root, FocusTab, 0)
+			return root, []Widget{root, a}
+		}},
+		{"tab index first", func() (Widget, []Widget) {
+			root := panel(nil, FocusNone, 0)
+			a := panel(root, FocusTab, 0)
+			b := panel(root, FocusTab, 2)
+			c := panel(root, FocusTab, 0)
+			d := panel(root, FocusTab, 1)
+			e := panel(root, FocusTab, 2)
+			return root, []Widget{d, b, e, a, c}
+		}},
+		{"hidden and disabled subtrees", func() (Widget, []Widget) {
+			root := panel(nil, FocusNone, 0)
+			h := panel(root, FocusTab, 0)
+			h.Hidden = true
+			panel(h, FocusTab, 0)
+			d := panel(root, FocusNone, 0)
+			d.Disabled = true
+			panel(d, FocusTab, 0)
+			a := panel(root, FocusTab, 0)
+			return root, []Widget{a}
+		}},
+		{"hidden root", func() (Widget, []Widget) {
+			top := panel(nil, FocusNone, 0)
+			top.Hidden = true
+			root := panel(top, FocusNone, 0)
+			panel(root, FocusTab, 0)
+			return root, nil
+		}},
+	}
+	for _, tt := range tests {
+		root, want := tt.build()
+		chain := FocusChain(root)
+		if len(chain) != len(want) {
+			t.Errorf("%s: got %d widgets, want %d", tt.name, len(chain), len(want))
+			continue
+		}
+		for i := range want {
+			if !chain[i].Equals(want[i]) {
+				t.Errorf("%s: widget %d differs", tt.name, i)
+			}
+		}
+	}
+}
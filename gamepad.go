@@ -0,0 +1,245 @@
+package grue
+
+import "math"
+
+// Joystick is index of joystick (gamepad) from 0 to MaxJoysticks-1.
+type Joystick int
+
+// MaxJoysticks is the number of joysticks tracked by surface.
+const MaxJoysticks = 16
+
+// GamepadButton is index of joystick button. Constants follow
+// XInput layout as GLFW 3.2 reports it (there is no Guide button).
+// Backends convert other layouts they know to it; unknown devices
+// may number buttons differently.
+type GamepadButton int
+
+// Gamepad buttons.
+const (
+	GamepadA GamepadButton = iota
+	GamepadB
+	GamepadX
+	GamepadY
+	GamepadLeftBumper
+	GamepadRightBumper
+	GamepadBack
+	GamepadStart
+	GamepadLeftThumb
+	GamepadRightThumb
+	GamepadDpadUp
+	GamepadDpadRight
+	GamepadDpadDown
+	GamepadDpadLeft
+)
+
+// Axes of the left stick. Axis values are from -1 to 1,
+// Y grows downwards on all platforms (backends flip it
+// where device reports it growing upwards).
+const (
+	GamepadAxisLeftX = 0
+	GamepadAxisLeftY = 1
+)
+
+// Stick deflection that counts as direction press.
+const gamepadStickThreshold = 0.5
+
+// ProcessGamepad handles gamepad navigation within root
+// (topmost popup, modal widget or root): D-pad or left stick
+// moves focus (see FocusInDirection), menu selection or current
+// row of focused list (ListView, TreeView, Table), A activates
+// focused widget or current row, B closes popups and cancels
+// dialogs. Returns true if input is consumed.
+func ProcessGamepad(s Surface, root Widget) bool {
+	for js := Joystick(0); js < MaxJoysticks; js++ {
+		if !s.JoystickPresent(js) {
+			continue
+		}
+		switch {
+		case s.JoystickJustPressed(js, GamepadA):
+			return gamepadActivate(s, root)
+		case s.JoystickJustPressed(js, GamepadB):
+			return gamepadCancel(s, root)
+		}
+		if sd, ok := gamepadDirection(s, js); ok {
+			if pm, ok := root.GetPanel().Virt.(*PopupMenu); ok {
+				switch sd {
+				case SideBelow:
+					pm.moveCurrent(1)
+				case SideAbove:
+					pm.moveCurrent(-1)
+				case SideRight:
+					pm.stepRight()
+				case SideLeft:
+					pm.stepLeft()
+				}
+				return true
+			}
+			if lv := focusedList(s, root); lv != nil && (sd == SideAbove || sd == SideBelow) {
+				i := lv.Current + 1
+				if sd == SideAbove {
+					i = lv.Current - 1
+				}
+				// Focus leaves list past its first or last row.
+				if i >= 0 && i < lv.Len() {
+					lv.moveTo(i)
+					return true
+				}
+			}
+			return FocusInDirection(s, root, sd)
+		}
+	}
+	return false
+}
+
+// Returns direction pressed on D-pad or by tilting left stick.
+func gamepadDirection(s Surface, js Joystick) (Side, bool) {
+	switch {
+	case s.JoystickJustPressed(js, GamepadDpadUp):
+		return SideAbove, true
+	case s.JoystickJustPressed(js, GamepadDpadDown):
+		return SideBelow, true
+	case s.JoystickJustPressed(js, GamepadDpadLeft):
+		return SideLeft, true
+	case s.JoystickJustPressed(js, GamepadDpadRight):
+		return SideRight, true
+	}
+	tilted := func(axis int) float64 {
+		v, pv := s.JoystickAxis(js, axis), s.JoystickPrevAxis(js, axis)
+		if math.Abs(v) >= gamepadStickThreshold && math.Abs(pv) < gamepadStickThreshold {
+			return v
+		}
+		return 0
+	}
+	switch x, y := tilted(GamepadAxisLeftX), tilted(GamepadAxisLeftY); {
+	case y < 0:
+		return SideAbove, true
+	case y > 0:
+		return SideBelow, true
+	case x < 0:
+		return SideLeft, true
+	case x > 0:
+		return SideRight, true
+	}
+	return SideBelow, false
+}
+
+// FocusInDirection moves focus to the nearest widget of the focus
+// chain of root at given side of focused one. Distance is measured
+// between centers of GlobalRects, with sideways offset weighted
+// twice. If nothing under root is focused, the first widget of the
+// chain gets focus. Returns false if focus is not moved.
+func FocusInDirection(s Surface, root Widget, sd Side) bool {
+	chain := FocusChain(root)
+	if len(chain) == 0 {
+		return false
+	}
+	f := s.Focus()
+	if f == nil || !root.GetPanel().IsAncestorOf(f) {
+		s.SetFocus(chain[0])
+		return true
+	}
+	var dir Vec
+	switch sd {
+	case SideBelow:
+		dir = V(0, -1)
+	case SideAbove:
+		dir = V(0, 1)
+	case SideRight:
+		dir = V(1, 0)
+	case SideLeft:
+		dir = V(-1, 0)
+	}
+	from := f.GetPanel().GlobalRect().Center()
+	var best Widget
+	bestScore := math.Inf(1)
+	for _, w := range chain {
+		if w.Equals(f) {
+			continue
+		}
+		d := w.GetPanel().GlobalRect().Center().Sub(from)
+		along := d.X*dir.X + d.Y*dir.Y
+		if along <= 0 {
+			continue
+		}
+		score := along + math.Abs(d.X*dir.Y-d.Y*dir.X)*2
+		if score < bestScore {
+			best, bestScore = w, score
+		}
+	}
+	if best == nil {
+		return false
+	}
+	s.SetFocus(best)
+	return true
+}
+
+// Activate selected menu option or focused widget.
+func gamepadActivate(s Surface, root Widget) bool {
+	if pm, ok := root.GetPanel().Virt.(*PopupMenu); ok {
+		if pm.Current >= 0 {
+			pm.enter(pm.Current)
+		}
+		return true
+	}
+	f := s.Focus()
+	if f == nil || !root.GetPanel().IsAncestorOf(f) || f.GetPanel().IsDisabled() {
+		return false
+	}
+	if f.GetPanel().OnGamepadActivate != nil {
+		f.GetPanel().OnGamepadActivate()
+		return true
+	}
+	if lv := focusedList(s, root); lv != nil {
+		if lv.Current >= 0 && lv.OnActivate != nil {
+			lv.OnActivate(lv.Current)
+		}
+		return true
+	}
+	switch w := f.GetPanel().Virt.(type) {
+	case *ComboBox:
+		w.Open()
+		return true
+	case interface{ Press() }:
+		w.Press()
+		return true
+	}
+	return false
+}
+
+// Returns list view of focused ListView, TreeView or
+// Table under root, nil if other widget is focused.
+func focusedList(s Surface, root Widget) *ListView {
+	f := s.Focus()
+	if f == nil || !root.GetPanel().IsAncestorOf(f) || f.GetPanel().IsDisabled() {
+		return nil
+	}
+	switch w := f.GetPanel().Virt.(type) {
+	case *ListView:
+		return w
+	case *TreeView:
+		return w.ListView
+	case *Table:
+		return w.ListView
+	}
+	return nil
+}
+
+// Close submenu or popups, or cancel modal dialog.
+func gamepadCancel(s Surface, root Widget) bool {
+	if pm, ok := root.GetPanel().Virt.(*PopupMenu); ok && pm.parentMenu != nil {
+		pm.parentMenu.closeSubmenu()
+		return true
+	}
+	if s.IsPopUpMode() {
+		s.PopDownTo(nil)
+		return true
+	}
+	if m := s.Modal(); m != nil {
+		if dlg, ok := m.GetPanel().Virt.(*Dialog); ok &&
+			dlg.CancelButton >= 0 && dlg.CancelButton < len(dlg.Buttons) {
+			dlg.Press(dlg.CancelButton)
+			return true
+		}
+	}
+	return false
+}
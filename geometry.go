@@ -162,6 +162,16 @@ const (
 	AlignCenter Align = 10
 )
 
+// Orientation defines direction in which widget is laid out.
+type Orientation int
+
+const (
+	// Horizontal orientation (left to right).
+	Horizontal Orientation = iota
+	// Vertical orientation (bottom to top).
+	Vertical
+)
+
 // AlignToRect returns a Vec that src Rect have to be
 // moved by in order to align relative to dst Rect given
 // alignments alh, alv.
@@ -212,3 +222,21 @@ func (r Rect) AlignToRect(dst Rect, al Align) Vec {
 func (r Rect) AlignToPoint(dst Vec, al Align) Vec {
 	return r.AlignToRect(Rect{dst, dst}, al)
 }
+
+// CornerRadii holds radii of rounded rectangle corners
+// in order: top left, top right, bottom right, bottom left.
+type CornerRadii [4]float64
+
+// Radii returns the same radius for all corners.
+func Radii(r float64) CornerRadii {
+	return CornerRadii{r, r, r, r}
+}
+
+// Fit returns radii limited so that corners fit into rect.
+func (cr CornerRadii) Fit(r Rect) CornerRadii {
+	max := math.Min(r.W(), r.H()) / 2
+	for i := range cr {
+		cr[i] = math.Max(0, math.Min(cr[i], max))
+	}
+	return cr
+}
@@ -45,9 +45,67 @@ type Surface interface {
 	// if it's under pointer coords
 	PopUpUnder(pos Vec) Widget
 
+	// Make widget modal: until it's closed or EndModal is called,
+	// only modal widget (and popups) receive input.
+	// Popups open before the call (except the one
+	// containing w) are closed.
+	// Modal widgets are stacked, the last one is active.
+	BeginModal(w Widget)
+
+	// Remove widget from modal stack.
+	EndModal(w Widget)
+
+	// Modal returns active modal widget or nil.
+	Modal() Widget
+
+	// StartDrag begins drag and drop operation.
+	// Drag ends when left mouse button is released
+	// or escape is pressed.
+	StartDrag(d *DragData)
+
+	// Drag returns data of active drag or nil.
+	Drag() *DragData
+
+	// CancelDrag ends active drag without dropping.
+	CancelDrag()
+
+	// EndDrag ends active drag without notifying widgets.
+	// It's called by ProcessDrag after drop.
+	EndDrag()
+
+	// Set function to be called when files are dropped onto
+	// the window from file manager and no widget under drop
+	// position handles them (see Interactive.OnFilesDropped).
+	SetOnFilesDropped(handler func(paths []string, pos Vec))
+
+	// Color mask is multiplied into colors of all following
+	// draw calls, nil means no mask. Panels set it while
+	// rendering (see Panel.Transparency and Panel.Tint).
+	SetColorMask(col color.Color)
+	ColorMask() color.Color
+
 	// Draw functions
 	DrawFillRect(r Rect, col color.Color)
 	DrawRect(r Rect, col color.Color, thick float64)
+	// Fill rectangle with linear gradient from c1 to c2,
+	// left to right (Horizontal) or top to bottom (Vertical).
+	DrawGradientRect(r Rect, c1, c2 color.Color, o Orientation)
+	// Fill ellipse inscribed into rectangle with radial gradient
+	// from inner color at the center to outer color at the edge.
+	DrawRadialGradient(r Rect, inner, outer color.Color)
+	DrawFillRoundedRect(r Rect, radii CornerRadii, col color.Color)
+	DrawRoundedRect(r Rect, radii CornerRadii, col color.Color, thick float64)
+	DrawLine(a, b Vec, col color.Color, thick float64)
+	DrawPolyline(points []Vec, col color.Color, thick float64)
+	DrawFillCircle(center Vec, radius float64, col color.Color)
+	DrawCircle(center Vec, radius float64, col color.Color, thick float64)
+	// Ellipse is inscribed into rectangle.
+	DrawFillEllipse(r Rect, col color.Color)
+	DrawEllipse(r Rect, col color.Color, thick float64)
+	// Draw soft shadow of rounded rectangle: it's filled
+	// with color, which fades out to transparent over
+	// blur distance outside of rectangle.
+	DrawShadow(r Rect, radii CornerRadii, col color.Color, blur float64)
 
 	// Font is a font name that was previously initialized.
 	// alh, alv -- horizontal and vertical text alignment (see Aling type)
@@ -75,9 +133,37 @@ type Surface interface {
 	ClickMousePos() Vec
 	JustPressed(button Button) bool
 	JustReleased(button Button) bool
+	Pressed(button Button) bool
 	KeysInput() string
 	Repeated(button Button) bool
 	MouseScroll() Vec
+	// Modifiers returns modifier keys being held.
+	Modifiers() Modifier
+	// KeyEvents returns keys pressed or repeated and text
+	// typed since the previous frame.
+	KeyEvents() []KeyEvent
+	// ConsumeKeyEvent hides key (or typed text) of event from
+	// KeyEvents and polling (JustPressed, Repeated, KeysInput)
+	// until the next frame. Pressed isn't affected.
+	ConsumeKeyEvent(e KeyEvent)
+	// Shortcuts returns registry of keyboard shortcuts
+	// of the surface.
+	Shortcuts() *Shortcuts
+
+	// Joysticks (gamepads). Axis values are from -1 to 1,
+	// buttons and axes follow layout of GamepadButton and
+	// GamepadAxis constants where backend can convert it.
+	// Prev getters return state of the previous frame.
+	JoystickPresent(js Joystick) bool
+	JoystickName(js Joystick) string
+	JoystickAxis(js Joystick, axis int) float64
+	JoystickPrevAxis(js Joystick, axis int) float64
+	JoystickPressed(js Joystick, button GamepadButton) bool
+	JoystickJustPressed(js Joystick, button GamepadButton) bool
+	JoystickJustReleased(js Joystick, button GamepadButton) bool
+	// SetOnJoystick sets handler called when joystick is
+	// connected or disconnected.
+	SetOnJoystick(handler func(js Joystick, connected bool))
 
 	// Load and init TTF font that will be known under given name
 	InitTTF(fontName, fileName string, size float64, charset Charset) error
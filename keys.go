@@ -146,3 +146,112 @@ const (
 	KeyMenu         = Button(pixelgl.KeyMenu)
 	KeyLast         = Button(pixelgl.KeyLast)
 )
+
+// Modifier is a set of held modifier keys (either left or right one).
+type Modifier int
+
+// Modifier keys.
+const (
+	ModShift Modifier = 1 << iota
+	ModControl
+	ModAlt
+	ModSuper
+)
+
+// ModifiersOf returns modifier keys held on surface.
+// Backends may use it to implement Surface.Modifiers.
+func ModifiersOf(s Surface) Modifier {
+	var m Modifier
+	if s.Pressed(KeyLeftShift) || s.Pressed(KeyRightShift) {
+		m |= ModShift
+	}
+	if s.Pressed(KeyLeftControl) || s.Pressed(KeyRightControl) {
+		m |= ModControl
+	}
+	if s.Pressed(KeyLeftAlt) || s.Pressed(KeyRightAlt) {
+		m |= ModAlt
+	}
+	if s.Pressed(KeyLeftSuper) || s.Pressed(KeyRightSuper) {
+		m |= ModSuper
+	}
+	return m
+}
+
+// KeyEvent is a key press (or its repeat) or typed text.
+type KeyEvent struct {
+	// Key is KeyUnknown for text event.
+	Key Button
+	// Mods are modifier keys held at the moment.
+	Mods Modifier
+	// Repeat is true for key repeated while being held.
+	Repeat bool
+	// Text typed (only for text event).
+	Text string
+}
+
+// Shortcut returns key chord of event.
+func (e KeyEvent) Shortcut() Shortcut {
+	return Shortcut{Key: e.Key, Mods: e.Mods}
+}
+
+// Is returns true if event is press (or repeat) of key
+// with exactly given modifiers.
+func (e KeyEvent) Is(key Button, mods Modifier) bool {
+	return e.Key == key && e.Mods == mods
+}
+
+// KeyEventsOf returns key events of surface for the current
+// frame, built from its polling state: keys just pressed or
+// repeated in order of key codes, then typed text.
+// Backends may use it to implement Surface.KeyEvents.
+func KeyEventsOf(s Surface) []KeyEvent {
+	var events []KeyEvent
+	mods := s.Modifiers()
+	for k := KeySpace; k <= KeyLast; k++ {
+		switch {
+		case s.JustPressed(k):
+			events = append(events, KeyEvent{Key: k, Mods: mods})
+		case s.Repeated(k):
+			events = append(events, KeyEvent{Key: k, Mods: mods, Repeat: true})
+		}
+	}
+	if t := s.KeysInput(); t != "" {
+		events = append(events, KeyEvent{Key: KeyUnknown, Mods: mods, Text: t})
+	}
+	return events
+}
+
+// ProcessKeyEvents delivers key events of the frame to target
+// (focused widget or input root if there is no focus). Event
+// not consumed by widget's OnKey is passed to its parent.
+// Hidden and disabled widgets are skipped. Consumed events are
+// hidden from polling handlers (see Surface.ConsumeKeyEvent).
+// Backends call it before polling handlers (OnKeys) and skip
+// them if it returns true: i.e. all events are consumed.
+func ProcessKeyEvents(s Surface, target Widget) bool {
+	events := s.KeyEvents()
+	if target == nil || len(events) == 0 {
+		return false
+	}
+	left := len(events)
+	for _, e := range events {
+		for w := target; w != nil; w = w.GetPanel().Parent {
+			p := w.GetPanel()
+			if p.OnKey == nil || !p.IsVisible() || p.IsDisabled() {
+				continue
+			}
+			if p.OnKey(e) {
+				s.ConsumeKeyEvent(e)
+				left--
+				break
+			}
+		}
+	}
+	return left == 0
+}
+
+// Returns state of Ctrl and Shift modifier keys.
+func modifiers(s Surface) (ctrl, shift bool) {
+	m := s.Modifiers()
+	return m&ModControl != 0, m&ModShift != 0
+}
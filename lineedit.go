@@ -18,6 +18,7 @@ func NewLineEdit(parent Widget, b Base) *LineEdit {
 		TextLimit: 1000,
 	}
 	InitWidget(parent, le)
+	le.FocusPolicy = FocusStrong
 
 	le.OnMouseDown = func(bt Button) {
 		if bt != MouseButtonLeft {
@@ -26,14 +27,14 @@ func NewLineEdit(parent Widget, b Base) *LineEdit {
 		le.CursorPos = len(le.Text)
 		le.TextOffset = 0
 	}
-	le.OnKeys = le.onKeys
+	le.OnKey = le.onKey
 
 	return le
 }
 
 // Paint draws the widget without children.
 func (le *LineEdit) Paint() {
-	editMode := le.Equals(le.Surface.Focus()) && !le.Disabled
+	editMode := le.Equals(le.Surface.Focus()) && !le.IsDisabled()
 	r := le.GlobalRect()
 	theme := le.MyTheme()
 	tdef, _ := theme.Drawers[ThemeLineEdit]
@@ -43,7 +44,7 @@ func (le *LineEdit) Paint() {
 	}
 	var tcur ThemeDrawer
 	switch {
-	case le.Disabled:
+	case le.IsDisabled():
 		tcur, _ = theme.Drawers[ThemeLineEditDisabled]
 		tcol = theme.DisabledTextColor
 	case editMode:
@@ -77,15 +78,21 @@ func (le *LineEdit) Paint() {
 	}
 }
 
-func (le *LineEdit) onKeys() bool {
-	switch {
-	case le.Surface.JustPressed(KeyEnter):
+func (le *LineEdit) onKey(e KeyEvent) bool {
+	if !le.Equals(le.Surface.Focus()) {
+		return false
+	}
+	switch e.Key {
+	case KeyEnter, KeyKPEnter:
+		if e.Repeat {
+			break
+		}
 		if le.OnEditingFinished != nil {
 			le.OnEditingFinished()
 		}
 		le.Surface.SetFocus(nil)
 		le.TextOffset = 0
-	case le.Surface.JustPressed(KeyBackspace) || le.Surface.Repeated(KeyBackspace):
+	case KeyBackspace:
 		if le.CursorPos == 0 {
 			break
 		}
@@ -96,7 +103,7 @@ func (le *LineEdit) onKeys() bool {
 		if le.OnTextChanged != nil {
 			le.OnTextChanged()
 		}
-	case le.Surface.JustPressed(KeyDelete) || le.Surface.Repeated(KeyDelete):
+	case KeyDelete:
 		if le.CursorPos >= len(le.Text) {
 			break
 		}
@@ -106,39 +113,45 @@ func (le *LineEdit) onKeys() bool {
 		if le.OnTextChanged != nil {
 			le.OnTextChanged()
 		}
-	case le.Surface.JustPressed(KeyLeft) || le.Surface.Repeated(KeyLeft):
+	case KeyLeft:
 		le.CursorPos--
 		if le.CursorPos < 0 {
 			le.CursorPos = 0
 		} else if le.CursorPos < le.TextOffset {
 			le.TextOffset = le.CursorPos
 		}
-	case le.Surface.JustPressed(KeyRight) || le.Surface.Repeated(KeyRight):
+	case KeyRight:
 		le.CursorPos++
 		if le.CursorPos > len(le.Text) {
 			le.CursorPos = len(le.Text)
 		}
-	case le.Surface.JustPressed(KeyHome):
+	case KeyHome:
 		le.CursorPos = 0
-	case le.Surface.JustPressed(KeyEnd):
+	case KeyEnd:
 		le.CursorPos = len(le.Text)
-	default:
-		if len(le.Text) >= le.TextLimit {
+	case KeyUnknown:
+		if len(le.Text) >= le.TextLimit || e.Text == "" {
 			break
 		}
-		t := le.Surface.KeysInput()
-		if len(t) > 0 {
-			le.Text = le.Text[:le.CursorPos] + t + le.Text[le.CursorPos:len(le.Text)]
-			le.CursorPos += len(t)
-		}
+		le.Text = le.Text[:le.CursorPos] + e.Text + le.Text[le.CursorPos:len(le.Text)]
+		le.CursorPos += len(e.Text)
 		if le.OnTextChanged != nil {
 			le.OnTextChanged()
 		}
+	default:
+		// Keys producing text are consumed along with it,
+		// others (Tab, chords) are left for parents.
+		return e.Mods&(ModControl|ModAlt|ModSuper) == 0 && typingKey(e.Key)
 	}
 	le.updateTextOffest()
 	return true
 }
 
+// Returns true if key types a character.
+func typingKey(k Button) bool {
+	return (k >= KeySpace && k <= KeyGraveAccent) || (k >= KeyKP0 && k <= KeyKPEqual)
+}
+
 // Return the horizontal position of cursor with current TextOffset
 func (le *LineEdit) cursorPos() float64 {
 	curRight := le.MyTheme().Pad
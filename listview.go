@@ -0,0 +1,535 @@
+package grue
+
+import (
+	"image/color"
+	"math"
+	"sort"
+)
+
+// ListItem is one item of list view.
+type ListItem struct {
+	ID    string
+	Text  string
+	Image string
+}
+
+// ListModel provides items for ListView.
+// Items are requested only for visible rows,
+// so model may produce them on the fly.
+type ListModel interface {
+	Len() int
+	Item(i int) ListItem
+}
+
+// ListItems is ListModel on top of slice of items.
+type ListItems []ListItem
+
+// Len ...
+func (li ListItems) Len() int {
+	return len(li)
+}
+
+// Item ...
+func (li ListItems) Item(i int) ListItem {
+	return li[i]
+}
+
+// SelectionMode defines how items are selected in views.
+type SelectionMode int
+
+const (
+	// SelectSingle allows only one item to be selected.
+	SelectSingle SelectionMode = iota
+	// SelectMulti toggles item selection on click.
+	SelectMulti
+	// SelectExtended selects one item on click,
+	// Ctrl+click toggles item and Shift+click selects range.
+	SelectExtended
+	// SelectNone disables selection.
+	SelectNone
+)
+
+// Interval between clicks to consider them a double click (seconds).
+const doubleClickTime = 0.4
+
+// ListView displays items of model in rows.
+// Only visible rows are drawn, so model
+// may contain any number of items.
+type ListView struct {
+	*Panel
+	// Model provides items. Current and Selected are indices
+	// of its items, list view doesn't remap them when model
+	// changes. Use SetModel to replace model, call
+	// ClearSelection (or SetModel with the same model)
+	// after changing items of the model in place.
+	Model ListModel
+	Mode  SelectionMode
+	// RowHeight is height of one row.
+	// If zero, it's derived from font height.
+	RowHeight float64
+	// Current is index of item with keyboard cursor, -1 if none.
+	Current int
+	// Offset is index of the first visible row.
+	Offset int
+	// Selected contains indices of selected items.
+	Selected map[int]bool
+	// AlternateRows makes odd rows drawn with
+	// ThemeListViewRowAlt drawer.
+	AlternateRows bool
+
+	OnSelectionChanged func()
+	// OnActivate is called on double click or Enter.
+	OnActivate func(index int)
+
+	// Start of range for Shift selection.
+	anchor int
+
+	lastClickTime float64
+	lastClickRow  int
+
+	dragThumb  bool
+	dragOffset int
+	dragPos    Vec
+
+	// Space at the top of widget not used by rows.
+	header float64
+	// Draws row content. Can be replaced by derived widgets.
+	drawRow func(i int, r Rect, textColor color.Color)
+}
+
+// NewListView creates new list view.
+func NewListView(parent Widget, b Base, model ListModel) *ListView {
+	lv := &ListView{
+		Panel:        NewPanel(nil, b),
+		Model:        model,
+		Current:      -1,
+		Selected:     make(map[int]bool),
+		lastClickRow: -1,
+	}
+	InitWidget(parent, lv)
+	lv.FocusPolicy = FocusStrong
+	lv.drawRow = lv.drawItem
+
+	lv.OnMouseDown = lv.onMouseDown
+	lv.OnMouseUp = func(bt Button) {
+		if bt == MouseButtonLeft {
+			lv.dragThumb = false
+		}
+	}
+	lv.OnMouseMove = lv.onMouseMove
+	lv.OnMouseWheel = func() {
+		lv.Scroll(-int(lv.Surface.MouseScroll().Y))
+	}
+	lv.OnKeys = lv.onKeys
+	return lv
+}
+
+// SetModel replaces model and resets selection, current item
+// and scroll position. OnSelectionChanged is called if any
+// item was selected.
+func (lv *ListView) SetModel(model ListModel) {
+	lv.Model = model
+	changed := len(lv.Selected) > 0
+	lv.ClearSelection()
+	lv.Current = -1
+	lv.anchor = 0
+	lv.Offset = 0
+	lv.lastClickRow = -1
+	if changed {
+		lv.selectionChanged()
+	}
+}
+
+// Len returns number of items in model.
+func (lv *ListView) Len() int {
+	if lv.Model == nil {
+		return 0
+	}
+	return lv.Model.Len()
+}
+
+// Rectangle for rows and scroll bar (screen coords).
+func (lv *ListView) viewRect() Rect {
+	// Leave some space for borders.
+	return lv.GlobalRect().Expanded(-lv.MyTheme().Pad/2).Extended(0, 0, 0, -lv.header)
+}
+
+// RowsRect returns rectangle occupied by rows (screen coords).
+func (lv *ListView) RowsRect() Rect {
+	r := lv.viewRect()
+	if lv.hasScrollBar() {
+		r.Max.X -= lv.MyTheme().scrollBarWidth()
+	}
+	return r
+}
+
+// VisibleRows returns number of rows fitting into widget.
+func (lv *ListView) VisibleRows() int {
+	r := lv.viewRect()
+	return int(math.Floor(r.H() / lv.rowHeight()))
+}
+
+// RowAt returns index of item at given position (screen coords),
+// -1 if there is none.
+func (lv *ListView) RowAt(pos Vec) int {
+	rr := lv.RowsRect()
+	if !rr.Contains(pos) {
+		return -1
+	}
+	i := lv.Offset + int((rr.Max.Y-pos.Y)/lv.rowHeight())
+	if i >= lv.Len() || i-lv.Offset >= lv.VisibleRows() {
+		return -1
+	}
+	return i
+}
+
+// RowRect returns rectangle of row with given index (screen coords).
+// Row might be out of view.
+func (lv *ListView) RowRect(i int) Rect {
+	rr := lv.RowsRect()
+	rh := lv.rowHeight()
+	y := rr.Max.Y - float64(i-lv.Offset+1)*rh
+	return R(rr.Min.X, y, rr.Max.X, y+rh)
+}
+
+// Scroll shifts visible rows by delta.
+func (lv *ListView) Scroll(delta int) {
+	lv.setOffset(lv.Offset + delta)
+}
+
+// ScrollTo scrolls view so item with given index is visible.
+func (lv *ListView) ScrollTo(i int) {
+	vis := lv.VisibleRows()
+	if i < lv.Offset {
+		lv.setOffset(i)
+	} else if i >= lv.Offset+vis {
+		lv.setOffset(i - vis + 1)
+	}
+}
+
+func (lv *ListView) setOffset(offs int) {
+	if offs > lv.Len()-lv.VisibleRows() {
+		offs = lv.Len() - lv.VisibleRows()
+	}
+	if offs < 0 {
+		offs = 0
+	}
+	lv.Offset = offs
+}
+
+// IsSelected checks if item is selected.
+func (lv *ListView) IsSelected(i int) bool {
+	return lv.Selected[i]
+}
+
+// SelectedIndices returns sorted indices of selected items.
+func (lv *ListView) SelectedIndices() []int {
+	res := make([]int, 0, len(lv.Selected))
+	for i := range lv.Selected {
+		res = append(res, i)
+	}
+	sort.Ints(res)
+	return res
+}
+
+// SetSelected selects or deselects item.
+// OnSelectionChanged is not called.
+func (lv *ListView) SetSelected(i int, on bool) {
+	if on {
+		lv.Selected[i] = true
+	} else {
+		delete(lv.Selected, i)
+	}
+}
+
+// ClearSelection deselects all items.
+// OnSelectionChanged is not called.
+func (lv *ListView) ClearSelection() {
+	lv.Selected = make(map[int]bool)
+}
+
+func (lv *ListView) selectRange(a, b int) {
+	if a > b {
+		a, b = b, a
+	}
+	if a < 0 {
+		a = 0
+	}
+	for i := a; i <= b; i++ {
+		lv.Selected[i] = true
+	}
+}
+
+func (lv *ListView) selectionChanged() {
+	if lv.OnSelectionChanged != nil {
+		lv.OnSelectionChanged()
+	}
+}
+
+// Select item clicked by mouse.
+func (lv *ListView) click(i int) {
+	lv.Current = i
+	ctrl, shift := modifiers(lv.Surface)
+	switch lv.Mode {
+	case SelectNone:
+		return
+	case SelectSingle:
+		lv.ClearSelection()
+		lv.Selected[i] = true
+		lv.anchor = i
+	case SelectMulti:
+		lv.SetSelected(i, !lv.Selected[i])
+		lv.anchor = i
+	case SelectExtended:
+		switch {
+		case shift:
+			if !ctrl {
+				lv.ClearSelection()
+			}
+			lv.selectRange(lv.anchor, i)
+		case ctrl:
+			lv.SetSelected(i, !lv.Selected[i])
+			lv.anchor = i
+		default:
+			lv.ClearSelection()
+			lv.Selected[i] = true
+			lv.anchor = i
+		}
+	}
+	lv.selectionChanged()
+}
+
+// Move keyboard cursor to item.
+func (lv *ListView) moveTo(i int) {
+	if lv.Len() == 0 {
+		return
+	}
+	if i >= lv.Len() {
+		i = lv.Len() - 1
+	}
+	if i < 0 {
+		i = 0
+	}
+	lv.Current = i
+	lv.ScrollTo(i)
+	ctrl, shift := modifiers(lv.Surface)
+	switch lv.Mode {
+	case SelectSingle:
+		lv.ClearSelection()
+		lv.Selected[i] = true
+		lv.anchor = i
+	case SelectExtended:
+		if shift {
+			if !ctrl {
+				lv.ClearSelection()
+			}
+			lv.selectRange(lv.anchor, i)
+		} else if !ctrl {
+			lv.ClearSelection()
+			lv.Selected[i] = true
+			lv.anchor = i
+		} else {
+			return
+		}
+	default:
+		return
+	}
+	lv.selectionChanged()
+}
+
+// Return thumb rect and bar rect of the scroll bar.
+func (lv *ListView) scrollBarRects() (thumb, bar Rect) {
+	theme := lv.MyTheme()
+	r := lv.viewRect()
+	bar = R(r.Max.X-theme.scrollBarWidth(), r.Min.Y, r.Max.X, r.Max.Y)
+	total, vis := lv.Len(), lv.VisibleRows()
+	th := math.Max(bar.W(), bar.H()*float64(vis)/float64(total))
+	top := bar.Max.Y - (bar.H()-th)*float64(lv.Offset)/float64(total-vis)
+	thumb = R(bar.Min.X, top-th, bar.Max.X, top)
+	return
+}
+
+func (lv *ListView) hasScrollBar() bool {
+	return lv.Len() > lv.VisibleRows()
+}
+
+func (lv *ListView) onMouseDown(bt Button) {
+	if lv.IsDisabled() {
+		return
+	}
+	pos := lv.Surface.MousePos()
+	if lv.hasScrollBar() {
+		thumb, bar := lv.scrollBarRects()
+		if bar.Contains(pos) {
+			if bt != MouseButtonLeft {
+				return
+			}
+			switch {
+			case thumb.Contains(pos):
+				lv.dragThumb = true
+				lv.dragOffset = lv.Offset
+				lv.dragPos = pos
+			case pos.Y > thumb.Max.Y:
+				lv.Scroll(-lv.VisibleRows())
+			default:
+				lv.Scroll(lv.VisibleRows())
+			}
+			return
+		}
+	}
+	i := lv.RowAt(pos)
+	if i < 0 {
+		return
+	}
+	lv.click(i)
+	if bt != MouseButtonLeft {
+		return
+	}
+	now := lv.Surface.TotalTime()
+	if i == lv.lastClickRow && now-lv.lastClickTime < doubleClickTime {
+		lv.lastClickRow = -1
+		if lv.OnActivate != nil {
+			lv.OnActivate(i)
+		}
+		return
+	}
+	lv.lastClickRow = i
+	lv.lastClickTime = now
+}
+
+func (lv *ListView) onMouseMove() {
+	if !lv.dragThumb {
+		return
+	}
+	if !lv.Surface.Pressed(MouseButtonLeft) {
+		lv.dragThumb = false
+		return
+	}
+	thumb, bar := lv.scrollBarRects()
+	free := bar.H() - thumb.H()
+	if free <= 0 {
+		return
+	}
+	dy := lv.dragPos.Y - lv.Surface.MousePos().Y
+	rows := float64(lv.Len() - lv.VisibleRows())
+	lv.setOffset(lv.dragOffset + int(math.Round(dy/free*rows)))
+}
+
+func (lv *ListView) onKeys() bool {
+	if lv.IsDisabled() || !lv.Equals(lv.Surface.Focus()) {
+		return false
+	}
+	s := lv.Surface
+	ctrl, _ := modifiers(lv.Surface)
+	switch {
+	case s.JustPressed(KeyDown) || s.Repeated(KeyDown):
+		lv.moveTo(lv.Current + 1)
+	case s.JustPressed(KeyUp) || s.Repeated(KeyUp):
+		lv.moveTo(lv.Current - 1)
+	case s.JustPressed(KeyPageDown) || s.Repeated(KeyPageDown):
+		lv.moveTo(lv.Current + lv.VisibleRows())
+	case s.JustPressed(KeyPageUp) || s.Repeated(KeyPageUp):
+		lv.moveTo(lv.Current - lv.VisibleRows())
+	case s.JustPressed(KeyHome):
+		lv.moveTo(0)
+	case s.JustPressed(KeyEnd):
+		lv.moveTo(lv.Len() - 1)
+	case s.JustPressed(KeySpace):
+		if lv.Current < 0 ||
+			!(lv.Mode == SelectMulti || (lv.Mode == SelectExtended && ctrl)) {
+			break
+		}
+		lv.SetSelected(lv.Current, !lv.Selected[lv.Current])
+		lv.anchor = lv.Current
+		lv.selectionChanged()
+	case ctrl && s.JustPressed(KeyA):
+		if lv.Mode != SelectMulti && lv.Mode != SelectExtended {
+			break
+		}
+		lv.selectRange(0, lv.Len()-1)
+		lv.selectionChanged()
+	case s.JustPressed(KeyEnter) || s.JustPressed(KeyKPEnter):
+		if lv.Current >= 0 && lv.OnActivate != nil {
+			lv.OnActivate(lv.Current)
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// Paint draws the widget without children.
+func (lv *ListView) Paint() {
+	r := lv.GlobalRect()
+	theme := lv.MyTheme()
+	tdef, _ := theme.Drawers[ThemeListView]
+	tcol := theme.ListTextColor
+	if tcol == nil {
+		tcol = theme.TextColor
+	}
+	if lv.IsDisabled() {
+		if tcur, _ := theme.Drawers[ThemeListViewDisabled]; tcur != nil {
+			tdef = tcur
+		}
+		tcol = theme.DisabledTextColor
+	}
+	if tdef != nil {
+		tdef.Draw(lv.Surface, r, lv.Extras...)
+	}
+
+	hover := -1
+	if lv.PointerInside && !lv.IsDisabled() && !lv.dragThumb {
+		hover = lv.RowAt(lv.Surface.MousePos())
+	}
+	focused := lv.Equals(lv.Surface.Focus())
+	last := lv.Offset + lv.VisibleRows()
+	if last > lv.Len() {
+		last = lv.Len()
+	}
+	for i := lv.Offset; i < last; i++ {
+		rr := lv.RowRect(i)
+		key := ThemeListViewRow
+		if lv.AlternateRows && i%2 == 1 {
+			key = ThemeListViewRowAlt
+		}
+		switch {
+		case lv.Selected[i]:
+			key = ThemeListViewRowSelected
+		case i == hover:
+			key = ThemeListViewRowHL
+		}
+		if td, _ := theme.Drawers[key]; td != nil {
+			td.Draw(lv.Surface, rr, lv.Extras...)
+		}
+		if focused && i == lv.Current {
+			if td, _ := theme.Drawers[ThemeListViewRowCurrent]; td != nil {
+				td.Draw(lv.Surface, rr, lv.Extras...)
+			}
+		}
+		lv.drawRow(i, rr, tcol)
+	}
+
+	if lv.hasScrollBar() {
+		thumb, bar := lv.scrollBarRects()
+		if td, _ := theme.Drawers[ThemeScrollBar]; td != nil {
+			td.Draw(lv.Surface, bar, lv.Extras...)
+		}
+		if td, _ := theme.Drawers[ThemeScrollBarThumb]; td != nil {
+			td.Draw(lv.Surface, thumb, lv.Extras...)
+		}
+	}
+}
+
+// Default row content: image and text of item.
+func (lv *ListView) drawItem(i int, r Rect, textColor color.Color) {
+	it := lv.Model.Item(i)
+	lv.DrawImageAndTextIn(r, it.Image, it.Text, textColor, AlignLeft, AlignLeft, Vec{})
+}
+
+func (lv *ListView) rowHeight() float64 {
+	if lv.RowHeight > 0 {
+		return lv.RowHeight
+	}
+	theme := lv.MyTheme()
+	return lv.Surface.GetTextRect("Wg", theme.TitleFont).H() + theme.Pad
+}
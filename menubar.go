@@ -0,0 +1,257 @@
+package grue
+
+import "math"
+
+// MenuBar is a horizontal bar of menus. Each menu is
+// a MenuOption which Submenu holds options of popup menu
+// opened below it. Mnemonic of menu text ("&File")
+// opens menu with Alt+character, mnemonics of options
+// work in popup menus. While menu is open,
+// Left and Right keys switch to neighbour menus.
+// Options with Shortcut are activated by it while
+// the bar is visible and enabled (see UpdateShortcuts).
+type MenuBar struct {
+	*Panel
+	Menus []MenuOption
+
+	popup *PopupMenu
+	// Index of menu which popup is open.
+	current int
+	// Registered shortcuts of options and registry
+	// they are in (nil if not registered yet).
+	shortcuts   []*ShortcutBinding
+	registry    *Shortcuts
+	shortcutErr error
+}
+
+// NewMenuBar creates new menu bar.
+func NewMenuBar(parent Widget, b Base, menus ...MenuOption) *MenuBar {
+	mb := &MenuBar{
+		Panel:   NewPanel(nil, b),
+		Menus:   menus,
+		current: -1,
+	}
+	InitWidget(parent, mb)
+
+	mb.OnMouseClick = func(bt Button) {
+		if bt != MouseButtonLeft || mb.IsDisabled() {
+			return
+		}
+		if i := mb.MenuAt(mb.Surface.MousePos()); i >= 0 {
+			mb.open(i, false)
+		}
+	}
+	mb.OnMouseMove = func() {
+		if !mb.IsOpen() {
+			return
+		}
+		if i := mb.MenuAt(mb.Surface.MousePos()); i >= 0 && i != mb.current {
+			mb.open(i, false)
+		}
+	}
+	mb.OnKeys = mb.onKeys
+	return mb
+}
+
+// UpdateShortcuts registers shortcuts of menu options (window
+// scope of the bar) replacing ones registered before. Bar does
+// it itself when it's drawn on a surface for the first time;
+// call it after changing Menus. Returns error of the first
+// shortcut which is invalid or conflicts (the others are
+// registered anyway), it's also kept for ShortcutsError.
+func (mb *MenuBar) UpdateShortcuts() error {
+	if mb.registry != nil {
+		for _, b := range mb.shortcuts {
+			mb.registry.Remove(b)
+		}
+	}
+	mb.shortcuts = nil
+	mb.registry = nil
+	if mb.Surface == nil {
+		mb.shortcutErr = nil
+		return nil
+	}
+	sr := mb.Surface.Shortcuts()
+	mb.registry = sr
+	var first error
+	var walk func(opts []MenuOption)
+	walk = func(opts []MenuOption) {
+		for i := range opts {
+			o := &opts[i]
+			if len(o.Submenu) > 0 {
+				walk(o.Submenu)
+				continue
+			}
+			if o.Shortcut == "" || o.Separator {
+				continue
+			}
+			k := i
+			b, err := sr.AddText(o.Shortcut, ShortcutWindow, mb, func() bool {
+				if !o.selectable() {
+					return false
+				}
+				toggleCheck(opts, k)
+				if o.Handler != nil {
+					o.Handler(nil)
+				}
+				return true
+			})
+			if err != nil {
+				if first == nil {
+					first = err
+				}
+				continue
+			}
+			mb.shortcuts = append(mb.shortcuts, b)
+		}
+	}
+	walk(mb.Menus)
+	mb.shortcutErr = first
+	return first
+}
+
+// ShortcutsError returns error of the last registration of
+// option shortcuts (see UpdateShortcuts), nil if all are fine.
+func (mb *MenuBar) ShortcutsError() error {
+	return mb.shortcutErr
+}
+
+// Current returns index of open menu, -1 if none.
+func (mb *MenuBar) Current() int {
+	if !mb.IsOpen() {
+		return -1
+	}
+	return mb.current
+}
+
+// IsOpen returns true if any menu of the bar is open.
+func (mb *MenuBar) IsOpen() bool {
+	return mb.popup != nil && mb.Surface.IsPopUp(mb.popup)
+}
+
+// Open shows popup menu by index with its first option selected.
+func (mb *MenuBar) Open(i int) {
+	mb.open(i, true)
+}
+
+func (mb *MenuBar) open(i int, selectFirst bool) {
+	if i < 0 || i >= len(mb.Menus) || !mb.Menus[i].selectable() {
+		return
+	}
+	mb.Surface.PopDownTo(nil)
+	mb.current = i
+	theme := mb.MyTheme()
+	step := menuStep(mb.Surface, theme)
+	r := mb.MenuRect(i)
+	opts := mb.Menus[i].Submenu
+	w := math.Max(menuWidth(mb.Surface, theme, step, opts, true), r.W())
+	mb.popup = NewPopupMenu(mb.Surface.Root(), Base{
+		Theme: mb.Theme,
+		Rect:  R0(w, step),
+	}, opts...)
+	mb.popup.Mnemonics = true
+	mb.popup.PlaceAt(r, SideBelow)
+	mb.popup.bar = mb
+	if selectFirst {
+		mb.popup.moveCurrent(1)
+	}
+	mb.Surface.SetFocus(mb.popup)
+}
+
+// Open next (delta > 0) or previous enabled menu.
+func (mb *MenuBar) step(delta int) {
+	n := len(mb.Menus)
+	i := mb.current
+	for k := 0; k < n; k++ {
+		i = (i + delta + n) % n
+		if mb.Menus[i].selectable() {
+			mb.open(i, true)
+			return
+		}
+	}
+}
+
+// MenuRect returns rectangle of menu title (screen coords).
+func (mb *MenuBar) MenuRect(i int) Rect {
+	theme := mb.MyTheme()
+	r := mb.GlobalRect()
+	x := r.Min.X
+	for k, m := range mb.Menus {
+		text, _, _ := mnemonic(m.Text)
+		w := mb.Surface.GetTextRect(text, theme.TitleFont).W() + theme.Pad*2
+		if m.Image != "" {
+			w += mb.Surface.GetImageRect(m.Image).W() + theme.Pad
+		}
+		if k == i {
+			return R(x, r.Min.Y, x+w, r.Max.Y)
+		}
+		x += w
+	}
+	return Rect{}
+}
+
+// MenuAt returns index of menu title at given position
+// (screen coords), -1 if there is none.
+func (mb *MenuBar) MenuAt(pos Vec) int {
+	for i := range mb.Menus {
+		if mb.MenuRect(i).Contains(pos) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Alt+mnemonic opens menu.
+func (mb *MenuBar) onKeys() bool {
+	s := mb.Surface
+	if mb.IsDisabled() || !(s.Pressed(KeyLeftAlt) || s.Pressed(KeyRightAlt)) {
+		return false
+	}
+	for i, m := range mb.Menus {
+		_, _, key := mnemonic(m.Text)
+		if key != KeyUnknown && m.selectable() && s.JustPressed(key) {
+			mb.open(i, true)
+			return true
+		}
+	}
+	return false
+}
+
+// Paint draws the widget without children.
+func (mb *MenuBar) Paint() {
+	// Register shortcuts on the first drawing
+	// or after moving to another surface.
+	if mb.registry != mb.Surface.Shortcuts() {
+		mb.UpdateShortcuts()
+	}
+	theme := mb.MyTheme()
+	if td, _ := theme.Drawers[ThemeMenuBar]; td != nil {
+		td.Draw(mb.Surface, mb.GlobalRect(), mb.Extras...)
+	}
+	textColor := theme.ButtonTextColor
+	if textColor == nil {
+		textColor = theme.TextColor
+	}
+	cur := mb.Current()
+	hover := -1
+	if mb.PointerInside {
+		hover = mb.MenuAt(mb.Surface.MousePos())
+	}
+	for i, m := range mb.Menus {
+		r := mb.MenuRect(i)
+		tcol := textColor
+		var td ThemeDrawer
+		switch {
+		case mb.IsDisabled() || m.Disabled:
+			tcol = theme.DisabledTextColor
+		case i == cur:
+			td, _ = theme.Drawers[ThemeMenuBarItemActive]
+		case i == hover:
+			td, _ = theme.Drawers[ThemeMenuBarItemHL]
+		}
+		if td != nil {
+			td.Draw(mb.Surface, r, mb.Extras...)
+		}
+		mb.drawMnemonicText(r, m.Image, m.Text, tcol, Vec{})
+	}
+}
@@ -0,0 +1,161 @@
+package grue
+
+// MouseEventType is type of mouse button event.
+type MouseEventType int
+
+// Mouse button events.
+const (
+	MouseDownEvent MouseEventType = iota
+	MouseUpEvent
+	MouseClickEvent
+)
+
+// EventPhase is a stage of event dispatch.
+type EventPhase int
+
+// Event phases.
+const (
+	// PhaseCapture goes from root down to parent of target.
+	PhaseCapture EventPhase = iota + 1
+	// PhaseTarget is delivery to target itself.
+	PhaseTarget
+	// PhaseBubble goes from parent of target up to root.
+	PhaseBubble
+)
+
+// MouseEvent is mouse button event dispatched
+// along path from root to widget under pointer.
+type MouseEvent struct {
+	Type   MouseEventType
+	Button Button
+	// Pos is pointer position (screen coords).
+	Pos Vec
+	// Target is widget under pointer.
+	Target Widget
+	// CurrentTarget is widget which handler is called.
+	CurrentTarget Widget
+	Phase         EventPhase
+
+	stopped   bool
+	prevented bool
+}
+
+// StopPropagation stops passing event to further widgets.
+// Handlers of the current widget and default action
+// are not affected.
+func (e *MouseEvent) StopPropagation() {
+	e.stopped = true
+}
+
+// PreventDefault cancels default action of event: focus and
+// OnMouseDown (and drag start) for down event, OnMouseUp for
+// up event, OnMouseClick and context menu for click event.
+func (e *MouseEvent) PreventDefault() {
+	e.prevented = true
+}
+
+// DefaultPrevented returns true if PreventDefault was called.
+func (e *MouseEvent) DefaultPrevented() bool {
+	return e.prevented
+}
+
+// DispatchMouseEvent delivers event to e.Target and its parents.
+// In capture phase OnMouseCapture of parents is called from root
+// down, then OnMouseEvent of target, then (bubble phase)
+// OnMouseEvent of parents up to root. Returns false if default
+// action is prevented.
+func DispatchMouseEvent(e *MouseEvent) bool {
+	var path []Widget
+	for w := e.Target; w != nil; w = w.GetPanel().Parent {
+		path = append(path, w)
+	}
+	if len(path) == 0 {
+		return true
+	}
+	call := func(w Widget, phase EventPhase, handler func(e *MouseEvent)) {
+		if handler == nil || e.stopped {
+			return
+		}
+		e.CurrentTarget = w
+		e.Phase = phase
+		handler(e)
+	}
+	for i := len(path) - 1; i > 0; i-- {
+		call(path[i], PhaseCapture, path[i].GetPanel().OnMouseCapture)
+	}
+	call(path[0], PhaseTarget, path[0].GetPanel().OnMouseEvent)
+	for _, w := range path[1:] {
+		call(w, PhaseBubble, w.GetPanel().OnMouseEvent)
+	}
+	e.CurrentTarget = nil
+	return !e.prevented
+}
+
+// Returns the nearest widget (starting from w and up to parents)
+// having button handlers or drag source. Children without them
+// (e.g. image inside of button) pass presses to parents.
+func mouseHandler(w Widget) *Panel {
+	for ; w != nil; w = w.GetPanel().Parent {
+		p := w.GetPanel()
+		if p.OnMouseDown != nil || p.OnMouseUp != nil || p.OnMouseClick != nil || p.DragSource != nil {
+			return p
+		}
+	}
+	return nil
+}
+
+// Returns the nearest widget (starting from w and up
+// to parents) which gets focus by mouse press.
+func clickFocus(w Widget) Widget {
+	for ; w != nil; w = w.GetPanel().Parent {
+		if w.GetPanel().FocusPolicy&FocusClick != 0 {
+			return w.GetPanel().Virt
+		}
+	}
+	return nil
+}
+
+// Dispatch button events of the frame with panel as target
+// and do default actions of events which are not prevented.
+func (p *Panel) dispatchButtons() {
+	s := p.Surface
+	for _, bt := range []Button{MouseButtonLeft, MouseButtonRight, MouseButtonMiddle} {
+		newEvent := func(t MouseEventType) *MouseEvent {
+			return &MouseEvent{Type: t, Button: bt, Pos: s.MousePos(), Target: p.Virt}
+		}
+		if s.JustPressed(bt) && DispatchMouseEvent(newEvent(MouseDownEvent)) {
+			h := mouseHandler(p.Virt)
+			// Widget handling press (e.g. button with image
+			// child) gets focus rather than the child.
+			if h != nil && h.FocusPolicy&FocusClick != 0 {
+				s.SetFocus(h.Virt)
+			} else if f := clickFocus(p.Virt); f != nil {
+				s.SetFocus(f)
+			}
+			if h != nil {
+				h.dragPending = bt == MouseButtonLeft && h.DragSource != nil
+				if h.OnMouseDown != nil {
+					h.OnMouseDown(bt)
+				}
+			}
+		}
+		if !s.JustReleased(bt) {
+			continue
+		}
+		if DispatchMouseEvent(newEvent(MouseUpEvent)) {
+			if h := mouseHandler(p.Virt); h != nil && h.OnMouseUp != nil {
+				h.OnMouseUp(bt)
+			}
+		}
+		len := s.PrevMousePos().Add(V(-s.ClickMousePos().X, -s.ClickMousePos().Y)).Len()
+		if len > clickDistance || !DispatchMouseEvent(newEvent(MouseClickEvent)) {
+			continue
+		}
+		if h := mouseHandler(p.Virt); h != nil && h.OnMouseClick != nil {
+			h.OnMouseClick(bt)
+		}
+		if bt == MouseButtonRight {
+			ShowContextMenu(p.Virt, s.MousePos())
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package grue
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestDispatchMouseEvent(t *testing.T) {
+	tests := []struct {
+		name string
+		// Widget name and phase to stop propagation
+		// or prevent default at ("" for none).
+		stopAt, preventAt string
+		want              []string
+		wantDefault       bool
+	}{
+		{"all phases", "", "",
+			[]string{"root:capture", "mid:capture", "leaf:target", "mid:bubble", "root:bubble"}, true},
+		{"stop in capture", "mid:capture", "",
+			[]string{"root:capture", "mid:capture"}, true},
+		{"stop at target", "leaf:target", "",
+			[]string{"root:capture", "mid:capture", "leaf:target"}, true},
+		{"stop in bubble", "mid:bubble", "",
+			[]string{"root:capture", "mid:capture", "leaf:target", "mid:bubble"}, true},
+		{"prevent in bubble", "", "mid:bubble",
+			[]string{"root:capture", "mid:capture", "leaf:target", "mid:bubble", "root:bubble"}, false},
+		{"prevent in capture and stop", "root:capture", "root:capture",
+			[]string{"root:capture"}, false},
+	}
+	phases := map[EventPhase]string{PhaseCapture: "capture", PhaseTarget: "target", PhaseBubble: "bubble"}
+	for _, tt := range tests {
+		root := NewPanel(nil, Base{})
+		mid := NewPanel(root, Base{})
+		leaf := NewPanel(mid, Base{})
+		var got []string
+		handler := func(name string, w Widget) func(e *MouseEvent) {
+			return func(e *MouseEvent) {
+				at := fmt.Sprintf("%s:%s", name, phases[e.Phase])
+				got = append(got, at)
+				if !e.CurrentTarget.Equals(w) || !e.Target.Equals(leaf) {
+					t.Errorf("%s: wrong current target or target at %s", tt.name, at)
+				}
+				if at == tt.stopAt {
+					e.StopPropagation()
+				}
+				if at == tt.preventAt {
+					e.PreventDefault()
+				}
+			}
+		}
+		for name, w := range map[string]*Panel{"root": root, "mid": mid, "leaf": leaf} {
+			w.OnMouseCapture = handler(name, w)
+			w.OnMouseEvent = handler(name, w)
+		}
+
+		e := &MouseEvent{Type: MouseDownEvent, Button: MouseButtonLeft, Target: leaf}
+		def := DispatchMouseEvent(e)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: handlers called %v, want %v", tt.name, got, tt.want)
+		}
+		if def != tt.wantDefault || e.DefaultPrevented() == tt.wantDefault {
+			t.Errorf("%s: DispatchMouseEvent = %v, want %v", tt.name, def, tt.wantDefault)
+		}
+		if e.CurrentTarget != nil {
+			t.Errorf("%s: CurrentTarget is not reset after dispatch", tt.name)
+		}
+	}
+}
+
+func TestDispatchMouseEventSkipsMissingHandlers(t *testing.T) {
+	root := NewPanel(nil, Base{})
+	mid := NewPanel(root, Base{})
+	leaf := NewPanel(mid, Base{})
+	var got []EventPhase
+	mid.OnMouseEvent = func(e *MouseEvent) {
+		got = append(got, e.Phase)
+	}
+	DispatchMouseEvent(&MouseEvent{Type: MouseClickEvent, Target: leaf})
+	// Capture handler of mid isn't set, so it's called
+	// in bubble phase only.
+	if want := []EventPhase{PhaseBubble}; !reflect.DeepEqual(got, want) {
+		t.Errorf("phases %v, want %v", got, want)
+	}
+}
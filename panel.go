@@ -18,6 +18,9 @@ type Base struct {
 	Image           string
 	ImageAlign      Align
 	PlaceholderText string
+	// Hidden widget and its children are neither rendered
+	// nor receive input. Use SetHidden to change it.
+	Hidden bool
 }
 
 // Panel is a simple widget with background color and border.
@@ -47,12 +50,26 @@ type Panel struct {
 	// True if pointer is inside the widget
 	PointerInside bool
 
+	// Left button is pressed over widget having DragSource.
+	dragPending bool
+
 	// Interactive provides response to input events.
 	Interactive
 
 	// Custom drawing function. Called from Paint.
 	OnDraw func()
 
+	// Called by SetHidden when panel is shown or hidden.
+	OnShow func()
+	OnHide func()
+
+	// Transparency (from 0, opaque, to 1, invisible) and Tint
+	// (nil for none) are multiplied into all drawing of panel
+	// and its children, including children own ones. Zero
+	// values leave drawing unchanged.
+	Transparency float64
+	Tint         color.Color
+
 	// Graphics surface.
 	Surface Surface
 }
@@ -67,8 +84,59 @@ type Interactive struct {
 	OnMouseClick func(button Button)
 	OnMouseWheel func()
 	OnKeys       func() bool
+	// OnKey is called for each key event of the frame while
+	// widget or one of its children has focus. If it returns
+	// false, event is passed to parent (see ProcessKeyEvents).
+	OnKey func(e KeyEvent) bool
+	// Button events are dispatched from root to widget under
+	// pointer: OnMouseCapture of parents is called first (root
+	// first), then OnMouseEvent of the widget and its parents.
+	// After that, if default isn't prevented, OnMouseDown, OnMouseUp
+	// and OnMouseClick are called for the nearest widget having
+	// any of them (see DispatchMouseEvent).
+	OnMouseCapture func(e *MouseEvent)
+	OnMouseEvent   func(e *MouseEvent)
+
+	// FocusPolicy defines if widget gets focus by click
+	// and/or Tab key. TabIndex (if positive) puts widget
+	// earlier in Tab order (see FocusChain).
+	FocusPolicy FocusPolicy
+	TabIndex    int
+	// Called when widget gets or loses focus.
+	OnFocusIn  func()
+	OnFocusOut func()
+	// OnGamepadActivate is called when focused widget is
+	// activated with gamepad A button (see ProcessGamepad).
+	OnGamepadActivate func()
+
+	// DragSource is called when pointer is moved with left
+	// button pressed over the widget. If it returns non-nil,
+	// drag and drop starts.
+	DragSource func() *DragData
+	// Drag and drop target handlers. OnDragEnter reports
+	// whether drag is accepted (see AcceptDrops); other
+	// handlers are called only for accepted drags.
+	OnDragEnter func(d *DragData) bool
+	OnDragOver  func(d *DragData)
+	OnDragLeave func(d *DragData)
+	OnDrop      func(d *DragData)
+
+	// ContextMenu options are shown in popup menu at pointer
+	// on right click or Menu key. If OnContextMenu is set,
+	// options returned by it are shown instead (no menu if nil).
+	// Widgets without context menu pass the request to parents.
+	ContextMenu   []MenuOption
+	OnContextMenu func() []MenuOption
+
+	// OnFilesDropped is called when files are dropped onto
+	// the widget from OS. pos is drop position (screen coords).
+	OnFilesDropped func(paths []string, pos Vec)
 }
 
+// Maximum pointer movement between press and release
+// for them to be considered a click.
+const clickDistance = 8
+
 // InitWidget initializes specific Widget behavior which must be
 // repeated in all panel descendants by actual type:
 // - sets up virtual;
@@ -88,6 +156,7 @@ func NewPanel(parent Widget, b Base) *Panel {
 	p := &Panel{
 		Base: b,
 	}
+	p.FocusPolicy = FocusClick
 	InitWidget(parent, p)
 	return p
 }
@@ -111,7 +180,7 @@ func (p *Panel) Paint() {
 		tcol = theme.TextColor
 	}
 	switch {
-	case p.Disabled:
+	case p.IsDisabled():
 		tcur, _ = theme.Drawers[ThemePanelDisabled]
 		tcol = theme.DisabledTextColor
 	}
@@ -129,9 +198,15 @@ func (p *Panel) Paint() {
 
 // DrawImageAndText draws image and/or text according to alignment.
 func (p *Panel) DrawImageAndText(image, text string, textColor color.Color, imageAl, textAl Align, disp Vec) {
+	p.DrawImageAndTextIn(p.GlobalRect(), image, text, textColor, imageAl, textAl, disp)
+}
+
+// DrawImageAndTextIn draws image and/or text according to alignment
+// inside of given rect (screen coords) instead of panel's rect.
+func (p *Panel) DrawImageAndTextIn(r Rect, image, text string, textColor color.Color, imageAl, textAl Align, disp Vec) {
 	theme := p.MyTheme()
 	imsz := p.Surface.GetImageRect(image).Size()
-	innerRect := p.GlobalRect().Expanded(-theme.Pad)
+	innerRect := r.Expanded(-theme.Pad)
 
 	if imageAl == AlignDefault {
 		imageAl = AlignLeft
@@ -192,15 +267,36 @@ func (p *Panel) Equals(w Widget) bool {
 
 // Render widget and its children on the screen.
 func (p *Panel) Render() {
+	if p.Hidden {
+		return
+	}
+	if p.Transparency != 0 || p.Tint != nil {
+		tint := p.Tint
+		if tint == nil {
+			tint = color.White
+		}
+		prev := p.Surface.ColorMask()
+		p.Surface.SetColorMask(ColorMultiply(prev, ColorFade(tint, 1-p.Transparency)))
+		defer p.Surface.SetColorMask(prev)
+	}
 	p.Virt.Paint()
 	for _, c := range p.Children {
 		c.Render()
 	}
+	p.drawFocusRing()
 }
 
 // ProcessMouse generates mouse events based on change in mouse coords.
 // wu holds top widget under mouse.
 func (p *Panel) ProcessMouse(wu Widget) {
+	if p.Hidden {
+		p.pointerLeft()
+		return
+	}
+	if p.dragPending {
+		p.checkDrag()
+	}
+
 	r := p.GlobalRect()
 	cont := r.Contains(p.Surface.MousePos())
 
@@ -220,31 +316,13 @@ func (p *Panel) ProcessMouse(wu Widget) {
 
 	p.Surface.SetToolTip(p.Tooltip)
 
-	checkPress := func(bt Button) {
-		if p.Surface.JustPressed(bt) {
-			p.Surface.SetFocus(p.Virt)
-			if p.OnMouseDown != nil {
-				p.OnMouseDown(bt)
-			}
-		}
-		if p.Surface.JustReleased(bt) {
-			if p.OnMouseUp != nil {
-				p.OnMouseUp(bt)
-			}
-			len := p.Surface.PrevMousePos().Add(
-				V(-p.Surface.ClickMousePos().X, -p.Surface.ClickMousePos().Y)).Len()
-			if len <= 8 && p.OnMouseClick != nil {
-				p.OnMouseClick(bt)
-			}
-		}
-	}
-	if p.Equals(wu) {
-		checkPress(MouseButtonLeft)
-		checkPress(MouseButtonRight)
-		checkPress(MouseButtonMiddle)
+	// Disabled widgets don't get focus and button events.
+	disabled := p.IsDisabled()
+	if p.Equals(wu) && !disabled {
+		p.dispatchButtons()
 	}
 
-	if p.Surface.MouseScroll() != V(0, 0) && p.OnMouseWheel != nil {
+	if p.Surface.MouseScroll() != V(0, 0) && p.OnMouseWheel != nil && !disabled {
 		p.OnMouseWheel()
 	}
 
@@ -261,10 +339,49 @@ func (p *Panel) ProcessMouse(wu Widget) {
 	}
 }
 
+// Clear PointerInside of panel and its children, calling
+// OnMouseOut of those pointer was inside of.
+func (p *Panel) pointerLeft() {
+	if p.PointerInside {
+		p.PointerInside = false
+		if p.OnMouseOut != nil {
+			p.OnMouseOut()
+		}
+	}
+	for _, c := range p.Children {
+		c.GetPanel().pointerLeft()
+	}
+}
+
+// Start drag if pointer moved far enough with left button pressed.
+func (p *Panel) checkDrag() {
+	s := p.Surface
+	if !s.Pressed(MouseButtonLeft) {
+		p.dragPending = false
+		return
+	}
+	if s.MousePos().Sub(s.ClickMousePos()).Len() <= clickDistance {
+		return
+	}
+	p.dragPending = false
+	d := p.DragSource()
+	if d == nil {
+		return
+	}
+	if d.Source == nil {
+		d.Source = p.Virt
+	}
+	s.StartDrag(d)
+}
+
 // ProcessKeys calls keyboard handlers on the widget
 // hierarchy. If any widget reports, that key is processed,
-// event propagation stops.
+// event propagation stops. Hidden and disabled subtrees
+// are skipped.
 func (p *Panel) ProcessKeys() {
+	if p.Hidden || p.Disabled {
+		return
+	}
 	if p.OnKeys != nil && p.OnKeys() {
 		return
 	}
@@ -276,12 +393,13 @@ func (p *Panel) ProcessKeys() {
 // WidgetUnder finds widget that is under given pointer coordinates
 func (p *Panel) WidgetUnder(pos Vec) Widget {
 	r := p.GlobalRect()
-	if !r.Contains(pos) {
+	if p.Hidden || !r.Contains(pos) {
 		return nil
 	}
 
-	for _, c := range p.Children {
-		wu := c.WidgetUnder(pos)
+	// Children drawn later are on top.
+	for i := len(p.Children) - 1; i >= 0; i-- {
+		wu := p.Children[i].WidgetUnder(pos)
 		if wu != nil {
 			return wu
 		}
@@ -289,6 +407,65 @@ func (p *Panel) WidgetUnder(pos Vec) Widget {
 	return p.Virt
 }
 
+// SetHidden hides or shows widget with its children and
+// calls OnHide or OnShow. Focus is moved from hidden
+// subtree to the parent.
+func (p *Panel) SetHidden(hidden bool) {
+	if p.Hidden == hidden {
+		return
+	}
+	p.Hidden = hidden
+	if !hidden {
+		if p.OnShow != nil {
+			p.OnShow()
+		}
+		return
+	}
+	p.pointerLeft()
+	if p.Surface != nil {
+		if f := p.Surface.Focus(); f != nil && p.IsAncestorOf(f) {
+			p.Surface.SetFocus(p.Parent)
+		}
+	}
+	if p.OnHide != nil {
+		p.OnHide()
+	}
+}
+
+// IsVisible returns true if neither widget nor any
+// of its parents is hidden.
+func (p *Panel) IsVisible() bool {
+	for w := Widget(p); w != nil; w = w.GetPanel().Parent {
+		if w.GetPanel().Hidden {
+			return false
+		}
+	}
+	return true
+}
+
+// IsDisabled returns true if widget or any of its parents
+// is disabled.
+func (p *Panel) IsDisabled() bool {
+	for w := Widget(p); w != nil; w = w.GetPanel().Parent {
+		if w.GetPanel().Disabled {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAncestorOf returns true if panel is widget itself
+// or one of its parents.
+func (p *Panel) IsAncestorOf(w Widget) bool {
+	for w != nil {
+		if p.Equals(w) {
+			return true
+		}
+		w = w.GetPanel().Parent
+	}
+	return false
+}
+
 // GlobalRect is absolute widget rectangle (screen coords).
 func (p *Panel) GlobalRect() (r Rect) {
 	r = p.Rect
@@ -320,6 +497,26 @@ func (p *Panel) Place(rel Vec) {
 	p.Rect.Max = p.Rect.Min.Add(sz)
 }
 
+// Raise moves widget above its siblings.
+func (p *Panel) Raise() {
+	if p.Parent == nil {
+		return
+	}
+	par := p.Parent.GetPanel()
+	par.removeChild(p.Virt)
+	par.addChild(p.Virt)
+}
+
+// Lower moves widget below its siblings.
+func (p *Panel) Lower() {
+	if p.Parent == nil {
+		return
+	}
+	par := p.Parent.GetPanel()
+	par.removeChild(p.Virt)
+	par.Children = append([]Widget{p.Virt}, par.Children...)
+}
+
 // Close widget and its children.
 func (p *Panel) Close() {
 	p.removeChildren()
@@ -0,0 +1,113 @@
+package pix
+
+import (
+	"runtime"
+
+	"github.com/faiface/mainthread"
+	"github.com/go-gl/glfw/v3.2/glfw"
+	"github.com/gremour/grue"
+)
+
+// State of joystick in one frame.
+type joystickState struct {
+	present bool
+	name    string
+	axes    []float32
+	buttons []byte
+}
+
+func (st joystickState) axis(axis int) float64 {
+	if axis < 0 || axis >= len(st.axes) {
+		return 0
+	}
+	return float64(st.axes[axis])
+}
+
+func (st joystickState) pressed(button grue.GamepadButton) bool {
+	return button >= 0 && int(button) < len(st.buttons) &&
+		st.buttons[button] == byte(glfw.Press)
+}
+
+// Convert raw GLFW 3.2 state to layout of grue constants:
+// XInput button order without Guide and Y axes growing down.
+func (st *joystickState) normalize() {
+	switch runtime.GOOS {
+	case "windows":
+		// XInput sticks report Y growing up. Axes are left X, Y,
+		// right X, Y, then triggers.
+		for _, i := range []int{grue.GamepadAxisLeftY, 3} {
+			if i < len(st.axes) {
+				st.axes[i] = -st.axes[i]
+			}
+		}
+	case "linux":
+		// Joydev (xpad) reports Guide after Start and D-pad as
+		// hat axes, which go after the other axes, unless the
+		// driver maps D-pad to buttons.
+		b := st.buttons
+		if len(b) > int(grue.GamepadRightThumb)+1 {
+			b = append(append([]byte(nil), b[:grue.GamepadLeftThumb]...), b[grue.GamepadLeftThumb+1:]...)
+		}
+		if n := len(st.axes); len(b) <= int(grue.GamepadDpadUp) && n >= 4 {
+			for len(b) < int(grue.GamepadDpadUp) {
+				b = append(b, byte(glfw.Release))
+			}
+			hx, hy := st.axes[n-2], st.axes[n-1]
+			b = append(b, hatButton(hy < -0.5), hatButton(hx > 0.5),
+				hatButton(hy > 0.5), hatButton(hx < -0.5))
+		}
+		st.buttons = b
+	}
+}
+
+func hatButton(pressed bool) byte {
+	if pressed {
+		return byte(glfw.Press)
+	}
+	return byte(glfw.Release)
+}
+
+// Returns state of joystick in current or previous frame.
+func (w *Window) joystick(js grue.Joystick, prev bool) joystickState {
+	if js < 0 || js >= grue.MaxJoysticks {
+		return joystickState{}
+	}
+	if prev {
+		return w.prevJoysticks[js]
+	}
+	return w.joysticks[js]
+}
+
+// Read joysticks state and notify surfaces about
+// connected and disconnected ones. Pixel doesn't expose
+// joysticks, so GLFW is polled directly.
+func (w *Window) pollJoysticks() {
+	w.prevJoysticks = w.joysticks
+	mainthread.Call(func() {
+		for i := range w.joysticks {
+			js := glfw.Joystick1 + glfw.Joystick(i)
+			st := joystickState{present: glfw.JoystickPresent(js)}
+			if st.present {
+				st.name = w.prevJoysticks[i].name
+				if !w.prevJoysticks[i].present {
+					st.name = glfw.GetJoystickName(js)
+				}
+				st.axes = glfw.GetJoystickAxes(js)
+				st.buttons = glfw.GetJoystickButtons(js)
+				st.normalize()
+			}
+			w.joysticks[i] = st
+		}
+	})
+	for i := range w.joysticks {
+		connected := w.joysticks[i].present
+		if connected == w.prevJoysticks[i].present {
+			continue
+		}
+		for _, s := range w.surfaces {
+			if s.onJoystick != nil {
+				s.onJoystick(grue.Joystick(i), connected)
+			}
+		}
+	}
+}
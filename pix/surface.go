@@ -20,11 +20,22 @@ type Surface struct {
 	Canvas *pixelgl.Canvas
 	Window *Window
 	Popups []grue.Widget
+	Modals []grue.Widget
+
+	drag *grue.DragData
 
 	Rect    grue.Rect
 	tooltip string
 	events  func()
 	root    grue.Widget
+	// Handler of files dropped from OS.
+	filesDropped func(paths []string, pos grue.Vec)
+	// Handler of joystick connection changes.
+	onJoystick func(js grue.Joystick, connected bool)
+	// Registry of keyboard shortcuts, created on demand.
+	shortcuts *grue.Shortcuts
+
+	colorMask color.Color
 
 	mousePos      grue.Vec
 	prevMousePos  grue.Vec
@@ -103,6 +114,22 @@ func (s *Surface) SetEvents(handler func()) {
 	s.events = handler
 }
 
+// SetOnFilesDropped sets handler for files dropped onto window
+// that aren't handled by widgets.
+func (s *Surface) SetOnFilesDropped(handler func(paths []string, pos grue.Vec)) {
+	s.filesDropped = handler
+}
+
+// Returns widget under pointer receiving input
+// (in popups or roots), nil if there is none.
+func (s *Surface) widgetUnder(roots []grue.Widget) grue.Widget {
+	wu := s.PopUpUnder(s.MousePos())
+	if wu == nil {
+		wu = roots[0].WidgetUnder(s.MousePos())
+	}
+	return wu
+}
+
 // SetToolTip ...
 func (s *Surface) SetToolTip(tooltip string) {
 	s.tooltip = tooltip
@@ -115,7 +142,17 @@ func (s *Surface) Root() grue.Widget {
 
 // SetFocus ...
 func (s *Surface) SetFocus(w grue.Widget) {
+	old := s.Window.focus
+	if old == nil && w == nil || old != nil && old.Equals(w) {
+		return
+	}
 	s.Window.focus = w
+	if old != nil && old.GetPanel().OnFocusOut != nil {
+		old.GetPanel().OnFocusOut()
+	}
+	if w != nil && w.GetPanel().OnFocusIn != nil {
+		w.GetPanel().OnFocusIn()
+	}
 }
 
 // Focus ...
@@ -195,6 +232,116 @@ func (s *Surface) IsPopUp(w grue.Widget) bool {
 	return false
 }
 
+// BeginModal ...
+func (s *Surface) BeginModal(w grue.Widget) {
+	if w == nil {
+		panic("trying to add empty modal")
+	}
+	for _, m := range s.Modals {
+		if m.Equals(w) {
+			return
+		}
+	}
+	// Popups opened before modal widget would keep receiving
+	// input, close them (except the one modal widget is in).
+	var keep grue.Widget
+	for _, p := range s.Popups {
+		if p.GetPanel().IsAncestorOf(w) {
+			keep = p
+		}
+	}
+	s.PopDownTo(keep)
+	s.Modals = append(s.Modals, w)
+}
+
+// EndModal ...
+func (s *Surface) EndModal(w grue.Widget) {
+	for i, m := range s.Modals {
+		if m.Equals(w) {
+			s.Modals = append(s.Modals[:i], s.Modals[i+1:]...)
+			return
+		}
+	}
+}
+
+// Modal ...
+func (s *Surface) Modal() grue.Widget {
+	// Drop modals that were closed without EndModal.
+	for len(s.Modals) > 0 {
+		m := s.Modals[len(s.Modals)-1]
+		if m.GetPanel().Parent != nil {
+			return m
+		}
+		s.Modals = s.Modals[:len(s.Modals)-1]
+	}
+	return nil
+}
+
+// Returns widgets that receive input: either root
+// or active modal widget with popups.
+func (s *Surface) inputRoots() []grue.Widget {
+	m := s.Modal()
+	if m == nil {
+		return []grue.Widget{s.root}
+	}
+	roots := []grue.Widget{m}
+	for _, p := range s.Popups {
+		if !m.GetPanel().IsAncestorOf(p) {
+			roots = append(roots, p)
+		}
+	}
+	return roots
+}
+
+// Checks if widget belongs to one of the input roots.
+func isUnder(roots []grue.Widget, w grue.Widget) bool {
+	for _, r := range roots {
+		if r.GetPanel().IsAncestorOf(w) {
+			return true
+		}
+	}
+	return false
+}
+
+// StartDrag ...
+func (s *Surface) StartDrag(d *grue.DragData) {
+	s.CancelDrag()
+	s.drag = d
+}
+
+// Drag ...
+func (s *Surface) Drag() *grue.DragData {
+	return s.drag
+}
+
+// CancelDrag ...
+func (s *Surface) CancelDrag() {
+	if s.drag == nil {
+		return
+	}
+	d := s.drag
+	s.drag = nil
+	d.Cancel()
+}
+
+// EndDrag ...
+func (s *Surface) EndDrag() {
+	s.drag = nil
+}
+
+// SetColorMask sets color multiplied into all drawing.
+func (s *Surface) SetColorMask(col color.Color) {
+	s.colorMask = col
+	if t, ok := s.Target().(interface{ SetColorMask(color.Color) }); ok {
+		t.SetColorMask(col)
+	}
+}
+
+// ColorMask returns color mask set by SetColorMask.
+func (s *Surface) ColorMask() color.Color {
+	return s.colorMask
+}
+
 // DrawFillRect draws filled rectangle.
 func (s *Surface) DrawFillRect(r grue.Rect, col color.Color) {
 	imd := imdraw.New(nil)
@@ -205,6 +352,191 @@ func (s *Surface) DrawFillRect(r grue.Rect, col color.Color) {
 	imd.Draw(s.Target())
 }
 
+// DrawGradientRect fills rectangle with linear gradient.
+func (s *Surface) DrawGradientRect(r grue.Rect, c1, c2 color.Color, o grue.Orientation) {
+	// Corners counter-clockwise from bottom left.
+	cols := []color.Color{c1, c2, c2, c1}
+	if o == grue.Vertical {
+		cols = []color.Color{c2, c2, c1, c1}
+	}
+	imd := imdraw.New(nil)
+	for i, v := range []pixel.Vec{
+		PVec(r.Min),
+		pixel.V(r.Max.X, r.Min.Y),
+		PVec(r.Max),
+		pixel.V(r.Min.X, r.Max.Y),
+	} {
+		imd.Color = cols[i]
+		imd.Push(v)
+	}
+	imd.Polygon(0)
+	imd.Draw(s.Target())
+}
+
+// DrawRadialGradient fills ellipse with radial gradient.
+func (s *Surface) DrawRadialGradient(r grue.Rect, inner, outer color.Color) {
+	c := r.Center()
+	rx, ry := r.W()/2, r.H()/2
+	n := ellipseSegments(rx, ry)
+	imd := imdraw.New(nil)
+	imd.Color = inner
+	imd.Push(PVec(c))
+	imd.Color = outer
+	for i := 0; i <= n; i++ {
+		a := 2 * math.Pi * float64(i) / float64(n)
+		imd.Push(pixel.V(c.X+rx*math.Cos(a), c.Y+ry*math.Sin(a)))
+	}
+	// Polygon is filled as a fan of triangles from the center.
+	imd.Polygon(0)
+	imd.Draw(s.Target())
+}
+
+// DrawFillRoundedRect draws filled rectangle with rounded corners.
+func (s *Surface) DrawFillRoundedRect(r grue.Rect, radii grue.CornerRadii, col color.Color) {
+	imd := imdraw.New(nil)
+	imd.Color = col
+	imd.Push(roundedRectPoints(r, radii.Fit(r), 0, cornerSegments(radii, 0))...)
+	imd.Polygon(0)
+	imd.Draw(s.Target())
+}
+
+// DrawRoundedRect draws outline of rectangle with rounded corners
+// with given line thickness. Outline is inside of rectangle.
+func (s *Surface) DrawRoundedRect(r grue.Rect, radii grue.CornerRadii, col color.Color, thick float64) {
+	r = r.Expanded(-thick / 2)
+	for i := range radii {
+		radii[i] -= thick / 2
+	}
+	imd := imdraw.New(nil)
+	imd.Color = col
+	imd.Push(roundedRectPoints(r, radii.Fit(r), 0, cornerSegments(radii, 0))...)
+	imd.Polygon(thick)
+	imd.Draw(s.Target())
+}
+
+// DrawLine draws line segment with given thickness.
+func (s *Surface) DrawLine(a, b grue.Vec, col color.Color, thick float64) {
+	s.DrawPolyline([]grue.Vec{a, b}, col, thick)
+}
+
+// DrawPolyline draws connected line segments with given thickness.
+func (s *Surface) DrawPolyline(points []grue.Vec, col color.Color, thick float64) {
+	if len(points) < 2 {
+		return
+	}
+	imd := imdraw.New(nil)
+	imd.Color = col
+	imd.EndShape = imdraw.RoundEndShape
+	for _, p := range points {
+		imd.Push(PVec(p))
+	}
+	imd.Line(thick)
+	imd.Draw(s.Target())
+}
+
+// DrawFillCircle draws filled circle.
+func (s *Surface) DrawFillCircle(center grue.Vec, radius float64, col color.Color) {
+	imd := imdraw.New(nil)
+	imd.Color = col
+	imd.Push(PVec(center))
+	imd.Circle(radius, 0)
+	imd.Draw(s.Target())
+}
+
+// DrawCircle draws circle outline with given thickness.
+// Outline is inside of circle.
+func (s *Surface) DrawCircle(center grue.Vec, radius float64, col color.Color, thick float64) {
+	imd := imdraw.New(nil)
+	imd.Color = col
+	imd.Push(PVec(center))
+	imd.Circle(radius-thick/2, thick)
+	imd.Draw(s.Target())
+}
+
+// DrawFillEllipse draws filled ellipse inscribed into rectangle.
+func (s *Surface) DrawFillEllipse(r grue.Rect, col color.Color) {
+	imd := imdraw.New(nil)
+	imd.Color = col
+	imd.Push(PVec(r.Center()))
+	imd.Ellipse(PVec(r.Size().Half()), 0)
+	imd.Draw(s.Target())
+}
+
+// DrawEllipse draws outline of ellipse inscribed into rectangle
+// with given thickness. Outline is inside of ellipse.
+func (s *Surface) DrawEllipse(r grue.Rect, col color.Color, thick float64) {
+	imd := imdraw.New(nil)
+	imd.Color = col
+	imd.Push(PVec(r.Center()))
+	imd.Ellipse(PVec(r.Expanded(-thick/2).Size().Half()), thick)
+	imd.Draw(s.Target())
+}
+
+// DrawShadow draws soft shadow of rounded rectangle.
+func (s *Surface) DrawShadow(r grue.Rect, radii grue.CornerRadii, col color.Color, blur float64) {
+	radii = radii.Fit(r)
+	n := cornerSegments(radii, blur)
+	inner := roundedRectPoints(r, radii, 0, n)
+	outer := roundedRectPoints(r, radii, blur, n)
+	imd := imdraw.New(nil)
+	imd.Color = col
+	imd.Push(inner...)
+	imd.Polygon(0)
+	// Ring between outlines fading out to transparent.
+	var transparent color.RGBA
+	for i := range inner {
+		j := (i + 1) % len(inner)
+		imd.Color = col
+		imd.Push(inner[i], inner[j])
+		imd.Color = transparent
+		imd.Push(outer[j], outer[i])
+		imd.Polygon(0)
+	}
+	imd.Draw(s.Target())
+}
+
+// Returns number of segments to approximate ellipse.
+func ellipseSegments(rx, ry float64) int {
+	return int(math.Max(16, math.Min(128, math.Max(rx, ry))))
+}
+
+// Returns number of segments to approximate each rounded
+// corner, enough for the largest of radii grown by grow.
+func cornerSegments(radii grue.CornerRadii, grow float64) int {
+	max := 0.0
+	for _, r := range radii {
+		max = math.Max(max, r+grow)
+	}
+	return int(math.Max(1, math.Min(16, math.Ceil(max/2))))
+}
+
+// Returns outline of rounded rectangle counter-clockwise,
+// starting at the right end of top edge. Corner arcs have
+// radii grown by grow (keeping their centers), so positive
+// grow gives outline around the rectangle.
+func roundedRectPoints(r grue.Rect, radii grue.CornerRadii, grow float64, segs int) []pixel.Vec {
+	// Corners in counter-clockwise order: top right, top left,
+	// bottom left, bottom right; arcs start at angle k*90.
+	corners := []struct {
+		rad    float64
+		center pixel.Vec
+	}{
+		{radii[1], pixel.V(r.Max.X-radii[1], r.Max.Y-radii[1])},
+		{radii[0], pixel.V(r.Min.X+radii[0], r.Max.Y-radii[0])},
+		{radii[3], pixel.V(r.Min.X+radii[3], r.Min.Y+radii[3])},
+		{radii[2], pixel.V(r.Max.X-radii[2], r.Min.Y+radii[2])},
+	}
+	points := make([]pixel.Vec, 0, len(corners)*(segs+1))
+	for k, c := range corners {
+		rad := math.Max(0, c.rad+grow)
+		for i := 0; i <= segs; i++ {
+			a := (float64(k) + float64(i)/float64(segs)) * math.Pi / 2
+			points = append(points, c.center.Add(pixel.V(math.Cos(a), math.Sin(a)).Scaled(rad)))
+		}
+	}
+	return points
+}
+
 // DrawRect draws rectlangle with given line thickness.
 func (s *Surface) DrawRect(r grue.Rect, col color.Color, thick float64) {
 	imd := imdraw.New(nil)
@@ -341,8 +673,9 @@ func (s *Surface) DrawTooltip() {
 	if drw == nil {
 		return
 	}
-	r := s.GetTextRect(s.tooltip, theme.TooltipFont)
-	r = r.Moved(s.MousePos()).Expanded(theme.Pad).Moved(grue.V(theme.Pad, theme.Pad))
+	r := s.GetTextRect(s.tooltip, theme.TooltipFont).Expanded(theme.Pad)
+	// Tooltip is shown above and to the right of pointer, if fits.
+	r = grue.PlaceRect(r.Size(), s.MousePos().ZR(), s.Rect, grue.SideAbove)
 	drw.Draw(s, r)
 	s.DrawText(s.tooltip, theme.TooltipFont, r, theme.TooltipColor, grue.AlignCenter)
 }
@@ -379,7 +712,7 @@ func (s *Surface) ClickMousePos() grue.Vec {
 
 // JustPressed getter.
 func (s *Surface) JustPressed(button grue.Button) bool {
-	return s.Window.JustPressed(pixelgl.Button(button))
+	return !s.Window.consumedKeys[button] && s.Window.JustPressed(pixelgl.Button(button))
 }
 
 // JustReleased getter.
@@ -387,14 +720,22 @@ func (s *Surface) JustReleased(button grue.Button) bool {
 	return s.Window.JustReleased(pixelgl.Button(button))
 }
 
+// Pressed getter.
+func (s *Surface) Pressed(button grue.Button) bool {
+	return s.Window.Pressed(pixelgl.Button(button))
+}
+
 // KeysInput ...
 func (s *Surface) KeysInput() string {
+	if s.Window.consumedText {
+		return ""
+	}
 	return s.Window.Typed()
 }
 
 // Repeated ...
 func (s *Surface) Repeated(button grue.Button) bool {
-	return s.Window.Repeated(pixelgl.Button(button))
+	return !s.Window.consumedKeys[button] && s.Window.Repeated(pixelgl.Button(button))
 }
 
 // MouseScroll getter.
@@ -402,6 +743,80 @@ func (s *Surface) MouseScroll() grue.Vec {
 	return GVec(s.Window.MouseScroll())
 }
 
+// Modifiers getter.
+func (s *Surface) Modifiers() grue.Modifier {
+	return grue.ModifiersOf(s)
+}
+
+// KeyEvents getter.
+func (s *Surface) KeyEvents() []grue.KeyEvent {
+	return grue.KeyEventsOf(s)
+}
+
+// ConsumeKeyEvent hides event from further processing in this frame.
+func (s *Surface) ConsumeKeyEvent(e grue.KeyEvent) {
+	w := s.Window
+	if e.Key == grue.KeyUnknown {
+		w.consumedText = true
+		return
+	}
+	if w.consumedKeys == nil {
+		w.consumedKeys = make(map[grue.Button]bool)
+	}
+	w.consumedKeys[e.Key] = true
+}
+
+// Shortcuts returns registry of keyboard shortcuts.
+func (s *Surface) Shortcuts() *grue.Shortcuts {
+	if s.shortcuts == nil {
+		s.shortcuts = grue.NewShortcuts()
+	}
+	return s.shortcuts
+}
+
+// JoystickPresent getter.
+func (s *Surface) JoystickPresent(js grue.Joystick) bool {
+	return s.Window.joystick(js, false).present
+}
+
+// JoystickName getter.
+func (s *Surface) JoystickName(js grue.Joystick) string {
+	return s.Window.joystick(js, false).name
+}
+
+// JoystickAxis getter.
+func (s *Surface) JoystickAxis(js grue.Joystick, axis int) float64 {
+	return s.Window.joystick(js, false).axis(axis)
+}
+
+// JoystickPrevAxis getter.
+func (s *Surface) JoystickPrevAxis(js grue.Joystick, axis int) float64 {
+	return s.Window.joystick(js, true).axis(axis)
+}
+
+// JoystickPressed getter.
+func (s *Surface) JoystickPressed(js grue.Joystick, button grue.GamepadButton) bool {
+	return s.Window.joystick(js, false).pressed(button)
+}
+
+// JoystickJustPressed getter.
+func (s *Surface) JoystickJustPressed(js grue.Joystick, button grue.GamepadButton) bool {
+	return s.Window.joystick(js, false).pressed(button) &&
+		!s.Window.joystick(js, true).pressed(button)
+}
+
+// JoystickJustReleased getter.
+func (s *Surface) JoystickJustReleased(js grue.Joystick, button grue.GamepadButton) bool {
+	return !s.Window.joystick(js, false).pressed(button) &&
+		s.Window.joystick(js, true).pressed(button)
+}
+
+// SetOnJoystick sets handler of joystick connection changes.
+// Joysticks connected at start are reported in the first frame.
+func (s *Surface) SetOnJoystick(handler func(js grue.Joystick, connected bool)) {
+	s.onJoystick = handler
+}
+
 // InitTTF ...
 func (s *Surface) InitTTF(fontName, fileName string, size float64, charset grue.Charset) error {
 	face, err := grue.LoadTTF(fileName, size)
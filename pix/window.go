@@ -4,9 +4,11 @@ package pix
 import (
 	"time"
 
+	"github.com/faiface/mainthread"
 	"github.com/faiface/pixel"
 	"github.com/faiface/pixel/pixelgl"
 	"github.com/faiface/pixel/text"
+	"github.com/go-gl/glfw/v3.2/glfw"
 	"github.com/gremour/grue"
 )
 
@@ -24,15 +26,78 @@ type Window struct {
 	sprites map[string]*pixel.Sprite
 
 	theme *grue.Theme
+
+	// Lists of files dropped from OS since the last frame.
+	dropped [][]string
+
+	// Keys and text consumed by key events in this frame.
+	consumedKeys map[grue.Button]bool
+	consumedText bool
+
+	// Joystick states of the current and previous frames.
+	joysticks     [grue.MaxJoysticks]joystickState
+	prevJoysticks [grue.MaxJoysticks]joystickState
 }
 
 func newWindow(win *pixelgl.Window, fps int) *Window {
-	return &Window{
+	w := &Window{
 		Window:  win,
 		fps:     fps,
 		fonts:   make(map[string]*text.Atlas),
 		sprites: make(map[string]*pixel.Sprite),
 	}
+	w.initDrop()
+	return w
+}
+
+// Set up GLFW drop callback. Pixel doesn't expose GLFW window,
+// but window's context is current after it has been created
+// or updated.
+func (w *Window) initDrop() {
+	mainthread.Call(func() {
+		gw := glfw.GetCurrentContext()
+		if gw == nil {
+			return
+		}
+		// Callback is called from Update on main thread,
+		// while Run is waiting for it.
+		gw.SetDropCallback(func(_ *glfw.Window, names []string) {
+			w.dropped = append(w.dropped, names)
+		})
+	})
+}
+
+// Deliver dropped files to widget under pointer on the topmost
+// surface having one (other than root). If it's not handled,
+// handler set by SetOnFilesDropped of that surface is called,
+// or of the topmost surface having it.
+func (w *Window) dropFiles(paths []string) {
+	var under *Surface
+	for i := len(w.surfaces) - 1; i >= 0; i-- {
+		s := w.surfaces[i]
+		if s.root == nil {
+			continue
+		}
+		wu := s.widgetUnder(s.inputRoots())
+		if wu == nil || wu.Equals(s.root) {
+			continue
+		}
+		if grue.DropFiles(wu, paths, s.MousePos()) {
+			return
+		}
+		under = s
+		break
+	}
+	if under != nil && under.filesDropped != nil {
+		under.filesDropped(paths, under.MousePos())
+		return
+	}
+	for i := len(w.surfaces) - 1; i >= 0; i-- {
+		if s := w.surfaces[i]; s.filesDropped != nil {
+			s.filesDropped(paths, s.MousePos())
+			return
+		}
+	}
 }
 
 // Run the main loop.
@@ -53,31 +118,78 @@ func (w *Window) Run() {
 			w.JustPressed(pixelgl.MouseButtonRight) ||
 			w.JustPressed(pixelgl.MouseButtonMiddle)
 
+		w.pollJoysticks()
+		w.consumedKeys = nil
+		w.consumedText = false
 		keyConsumed := false
 		for _, s := range w.surfaces {
 			s.updateMousePos(GVec(w.MousePosition()), click)
+		}
+		for _, paths := range w.dropped {
+			w.dropFiles(paths)
+		}
+		w.dropped = nil
+		for _, s := range w.surfaces {
 			if s.root != nil {
-				wu := s.PopUpUnder(s.MousePos())
-				if wu == nil {
-					wu = s.Root().WidgetUnder(s.MousePos())
-				}
+				roots := s.inputRoots()
+				wu := s.widgetUnder(roots)
 				closePopup := s.IsPopUpMode() &&
 					(w.JustPressed(pixelgl.KeyEscape) ||
 						(!s.IsPopUp(wu) && w.JustReleased(pixelgl.MouseButtonLeft)))
-				if closePopup {
+				if s.Drag() != nil {
+					grue.ProcessDrag(s, wu)
+				} else if closePopup {
 					s.PopDownTo(nil)
 				} else {
-					s.root.ProcessMouse(wu)
+					for _, r := range roots {
+						r.ProcessMouse(wu)
+					}
+					if !keyConsumed {
+						keyConsumed = grue.ProcessShortcuts(s, roots)
+					}
 					if !keyConsumed {
-						if s.Focus() != nil && s.Focus().GetPanel().OnKeys != nil {
-							keyConsumed = s.Focus().GetPanel().OnKeys()
+						// Topmost popup, modal widget or root.
+						top := roots[0]
+						if len(s.Popups) > 0 {
+							top = s.Popups[len(s.Popups)-1]
+						}
+						f := s.Focus()
+						if f != nil && (!isUnder(roots, f) || !f.GetPanel().IsVisible()) {
+							f = nil
+						}
+						if f == nil {
+							keyConsumed = grue.ProcessContextMenuKey(s, wu)
+						} else {
+							keyConsumed = grue.ProcessContextMenuKey(s, f)
+						}
+						if !keyConsumed {
+							if f == nil {
+								keyConsumed = grue.ProcessKeyEvents(s, top)
+							} else {
+								keyConsumed = grue.ProcessKeyEvents(s, f)
+							}
+						}
+						if !keyConsumed && f != nil && f.GetPanel().OnKeys != nil && !f.GetPanel().IsDisabled() {
+							keyConsumed = f.GetPanel().OnKeys()
 						}
 						if !keyConsumed {
-							s.root.ProcessKeys()
+							// Tab moves focus within top.
+							keyConsumed = grue.ProcessFocusKeys(s, top)
+							if !keyConsumed {
+								keyConsumed = grue.ProcessGamepad(s, top)
+							}
+						}
+						if !keyConsumed {
+							for _, r := range roots {
+								r.ProcessKeys()
+							}
 						}
 					}
 				}
 				s.root.Render()
+				if s.Drag() != nil {
+					s.Drag().Draw(s)
+				}
 				if s.events != nil {
 					s.events()
 				}
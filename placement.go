@@ -0,0 +1,88 @@
+package grue
+
+// Side is a side of anchor rectangle to place popup at.
+type Side int
+
+const (
+	// SideBelow places popup below anchor, left edges aligned.
+	SideBelow Side = iota
+	// SideAbove places popup above anchor, left edges aligned.
+	SideAbove
+	// SideRight places popup to the right of anchor, top edges aligned.
+	SideRight
+	// SideLeft places popup to the left of anchor, top edges aligned.
+	SideLeft
+)
+
+// Opposite returns the opposite side.
+func (sd Side) Opposite() Side {
+	switch sd {
+	case SideBelow:
+		return SideAbove
+	case SideAbove:
+		return SideBelow
+	case SideRight:
+		return SideLeft
+	}
+	return SideRight
+}
+
+// Returns rect of given size placed at side of anchor.
+func sideRect(size Vec, anchor Rect, sd Side) Rect {
+	switch sd {
+	case SideAbove:
+		return R(anchor.Min.X, anchor.Max.Y, anchor.Min.X+size.X, anchor.Max.Y+size.Y)
+	case SideRight:
+		return R(anchor.Max.X, anchor.Max.Y-size.Y, anchor.Max.X+size.X, anchor.Max.Y)
+	case SideLeft:
+		return R(anchor.Min.X-size.X, anchor.Max.Y-size.Y, anchor.Min.X, anchor.Max.Y)
+	}
+	return R(anchor.Min.X, anchor.Min.Y-size.Y, anchor.Min.X+size.X, anchor.Min.Y)
+}
+
+// Returns amount of space at side of anchor within bounds.
+func sideSpace(anchor, bounds Rect, sd Side) float64 {
+	switch sd {
+	case SideAbove:
+		return bounds.Max.Y - anchor.Max.Y
+	case SideRight:
+		return bounds.Max.X - anchor.Max.X
+	case SideLeft:
+		return anchor.Min.X - bounds.Min.X
+	}
+	return anchor.Min.Y - bounds.Min.Y
+}
+
+// PlaceRect returns rectangle of given size placed at preferred
+// side of anchor. If it doesn't fit into bounds there, but fits
+// (or has more space) at the opposite side, it's flipped. Then
+// rectangle is shifted to stay inside of bounds as much as possible.
+func PlaceRect(size Vec, anchor, bounds Rect, sd Side) Rect {
+	need := size.Y
+	if sd == SideRight || sd == SideLeft {
+		need = size.X
+	}
+	if sp := sideSpace(anchor, bounds, sd); sp < need && sideSpace(anchor, bounds, sd.Opposite()) > sp {
+		sd = sd.Opposite()
+	}
+	return sideRect(size, anchor, sd).Shifted(bounds)
+}
+
+// Shifted returns rectangle moved to be inside of bounds.
+// If it's bigger than bounds, its left top corner is kept inside.
+func (r Rect) Shifted(bounds Rect) Rect {
+	d := Vec{}
+	if r.Max.X > bounds.Max.X {
+		d.X = bounds.Max.X - r.Max.X
+	}
+	if r.Min.X+d.X < bounds.Min.X {
+		d.X = bounds.Min.X - r.Min.X
+	}
+	if r.Min.Y < bounds.Min.Y {
+		d.Y = bounds.Min.Y - r.Min.Y
+	}
+	if r.Max.Y+d.Y > bounds.Max.Y {
+		d.Y = bounds.Max.Y - r.Max.Y
+	}
+	return r.Moved(d)
+}
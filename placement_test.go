@@ -0,0 +1,34 @@
+package grue
+
+import "testing"
+
+func TestPlaceRect(t *testing.T) {
+	bounds := R(0, 0, 100, 100)
+	tests := []struct {
+		name   string
+		size   Vec
+		anchor Rect
+		sd     Side
+		want   Rect
+	}{
+		{"below", V(20, 30), R(10, 50, 40, 60), SideBelow, R(10, 20, 30, 50)},
+		{"above", V(20, 30), R(10, 50, 40, 60), SideAbove, R(10, 60, 30, 90)},
+		{"right", V(20, 30), R(10, 50, 40, 60), SideRight, R(40, 30, 60, 60)},
+		{"left", V(20, 30), R(50, 50, 60, 60), SideLeft, R(30, 30, 50, 60)},
+		{"below flipped", V(20, 30), R(10, 10, 40, 20), SideBelow, R(10, 20, 30, 50)},
+		{"above flipped", V(20, 30), R(10, 80, 40, 90), SideAbove, R(10, 50, 30, 80)},
+		{"right flipped", V(20, 30), R(70, 50, 90, 60), SideRight, R(50, 30, 70, 60)},
+		{"left flipped", V(20, 30), R(10, 50, 20, 60), SideLeft, R(20, 30, 40, 60)},
+		// Neither side fits, below has more space.
+		{"below shifted", V(20, 50), R(10, 40, 40, 75), SideBelow, R(10, 0, 30, 50)},
+		{"shifted left", V(20, 30), R(90, 50, 100, 60), SideBelow, R(80, 20, 100, 50)},
+		// Left top corner stays inside of bounds.
+		{"too wide", V(150, 30), R(10, 50, 40, 60), SideBelow, R(0, 20, 150, 50)},
+		{"too tall", V(20, 150), R(10, 50, 40, 60), SideBelow, R(10, -50, 30, 100)},
+	}
+	for _, tt := range tests {
+		if r := PlaceRect(tt.size, tt.anchor, bounds, tt.sd); r != tt.want {
+			t.Errorf("%s: PlaceRect = %v, want %v", tt.name, r, tt.want)
+		}
+	}
+}
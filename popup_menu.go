@@ -1,93 +1,585 @@
 package grue
 
-import "fmt"
+import (
+	"image/color"
+	"math"
+)
 
 // PopupMenu is menu to use as popup.
 // Menu contains a number of options
 // which are represented as buttons
 // and handlers for each option activation.
-// To create hierarchical menu,
-// create new popup menu from option handler.
+// Options having Submenu open cascading menu
+// next to them.
 type PopupMenu struct {
 	*Panel
 
-	opts []optWidget
-}
+	// Current is index of option selected with keyboard,
+	// -1 if none.
+	Current int
+	// Mnemonics enables mnemonics in option texts (see
+	// MenuOption.Text). It's set for menus of MenuBar and
+	// context menus and inherited by submenus. Otherwise
+	// texts are shown as is.
+	Mnemonics bool
+
+	opts    []MenuOption
+	buttons []*menuItem
+	// Index of option shown by the first button.
+	first int
+	// Distance between options vertically.
+	step float64
 
-type optWidget struct {
-	opt MenuOption
-	w   Widget
+	// Open submenu and option which opened it.
+	child       *PopupMenu
+	parentMenu  *PopupMenu
+	parentIndex int
+	// Menu bar that opened menu (nil if none).
+	bar *MenuBar
+
+	hasChecks   bool
+	hasSubmenus bool
 }
 
 // MenuOption is one option for popup menu.
 type MenuOption struct {
 	// Identifier string to search options by it.
-	ID       string
+	ID string
+	// Text of option. If menu has Mnemonics, character
+	// after '&' is mnemonic: it's underlined and activates
+	// option when typed ("&Open"). Use "&&" for ampersand.
 	Text     string
 	Image    string
 	Disabled bool
-	// Handler is called when option is activated
+	// Shortcut is text shown at the right side (e.g. "Ctrl+S").
+	// Options of menu bar are activated by it as well
+	// (see ParseShortcut and MenuBar.UpdateShortcuts).
+	Shortcut string
+	// Separator is drawn as a line and can't be activated.
+	Separator bool
+	// Checkable option shows check mark if Checked and
+	// is toggled on activation. Checkable options with
+	// the same non-empty Group work as radio buttons.
+	// Checked state is changed in options slice passed
+	// to menu, so it persists if the same slice is used.
+	Checkable bool
+	Checked   bool
+	Group     string
+	// Submenu options are shown in cascading menu.
+	Submenu []MenuOption
+	// Handler is called when option is activated.
+	// pm is nil if option is activated by shortcut.
 	Handler func(pm *PopupMenu) bool
 }
 
+// Returns true if option can be selected and activated.
+func (o *MenuOption) selectable() bool {
+	return !o.Disabled && !o.Separator
+}
+
 // NewPopupMenu creates new popup menu.
 // Menu is located relative to topleft of
 // provided Rect (in Base). Height is used
 // as distance between options vertically
 // (including height of button).
+// If menu doesn't fit below that point, it's shown
+// above it. It's also shifted to stay inside of surface.
+// Use PlaceAt to position menu relative to other rect.
 func NewPopupMenu(parent Widget, b Base, mo ...MenuOption) *PopupMenu {
+	return NewScrollPopupMenu(parent, b, len(mo), mo...)
+}
+
+// NewScrollPopupMenu creates new popup menu showing
+// at most visible options at once. The rest of options
+// is reached by mouse wheel or keyboard.
+// Rect in Base is interpreted as in NewPopupMenu.
+func NewScrollPopupMenu(parent Widget, b Base, visible int, mo ...MenuOption) *PopupMenu {
 	pm := &PopupMenu{
-		Panel: NewPanel(nil, b),
+		Panel:       NewPanel(nil, b),
+		Current:     -1,
+		opts:        mo,
+		step:        b.Rect.H(),
+		parentIndex: -1,
 	}
 	InitWidget(parent, pm)
+	if visible <= 0 || visible > len(mo) {
+		visible = len(mo)
+	}
+	for _, o := range mo {
+		pm.hasChecks = pm.hasChecks || o.Checkable
+		pm.hasSubmenus = pm.hasSubmenus || len(o.Submenu) > 0
+	}
 	pad := pm.MyTheme().Pad
 	btH := b.Rect.H() - pad
 	btW := b.Rect.W() - pad*2
-	pm.Rect = R(pm.Rect.Min.X, pm.Rect.Max.Y-pm.Rect.H()*float64(len(mo))-pad,
+	pm.Rect = R(pm.Rect.Min.X, pm.Rect.Max.Y-pm.Rect.H()*float64(visible)-pad,
 		pm.Rect.Max.X, pm.Rect.Max.Y)
 	y := pm.Rect.H() - pad
-	for _, o := range mo {
-		bt := NewPushButton(pm, Base{
-			Rect:     R(pad, y-btH, pad+btW, y),
-			Text:     o.Text,
-			Image:    o.Image,
-			Disabled: o.Disabled,
-		})
+	for i := 0; i < visible; i++ {
+		o := mo[i]
+		bt := &menuItem{
+			PushButton: NewPushButton(nil, Base{
+				Rect:     R(pad, y-btH, pad+btW, y),
+				Text:     o.Text,
+				Image:    o.Image,
+				Disabled: o.Disabled || o.Separator,
+			}),
+			pm:    pm,
+			index: i,
+		}
+		InitWidget(pm, bt)
+		// Menu handles keys itself, items aren't in Tab order.
+		bt.FocusPolicy = FocusClick
 		y -= btH + pad
-		o := o
+		i := i
 		bt.OnPress = func() {
-			fmt.Printf("popup menu onpress before popdown o=%+v\n", o)
-			bt.Surface.PopDownTo(pm)
-			fmt.Printf("popup menu onpress after popdown o=%+v\n", o)
-			if o.Handler != nil {
-				fmt.Printf("popup menu onpress before handler\n")
-				close := o.Handler(pm)
-				fmt.Printf("popup menu onpress after handler =%v\n", close)
-				if close {
-					pm.Surface.PopDownTo(nil)
-				}
-			}
+			pm.activate(pm.first + i)
 		}
-		ow := optWidget{
-			opt: o,
-			w:   bt,
+		bt.OnMouseIn = func() {
+			pm.hover(pm.first + i)
 		}
-		pm.opts = append(pm.opts, ow)
+		pm.buttons = append(pm.buttons, bt)
+	}
+	pm.OnMouseWheel = func() {
+		pm.Scroll(-int(pm.Surface.MouseScroll().Y))
 	}
+	pm.OnKeys = pm.onKeys
+	g := pm.GlobalRect()
+	pm.PlaceAt(R(g.Min.X, g.Max.Y, g.Min.X, g.Max.Y), SideBelow)
 	pm.Surface.PopUp(pm)
 	return pm
 }
 
 // GetButton returns option's button by ID.
+// Returns nil if option is scrolled out of view.
 func (pm *PopupMenu) GetButton(id string) *PushButton {
-	for _, ow := range pm.opts {
-		if ow.opt.ID == id {
-			pb, ok := ow.w.(*PushButton)
-			if !ok {
+	for i, o := range pm.opts {
+		if o.ID == id {
+			i -= pm.first
+			if i < 0 || i >= len(pm.buttons) {
 				return nil
 			}
-			return pb
+			return pm.buttons[i].PushButton
+		}
+	}
+	return nil
+}
+
+// Option returns option by ID, nil if not found.
+func (pm *PopupMenu) Option(id string) *MenuOption {
+	for i := range pm.opts {
+		if pm.opts[i].ID == id {
+			return &pm.opts[i]
 		}
 	}
 	return nil
 }
+
+// Scroll shifts visible options by delta.
+func (pm *PopupMenu) Scroll(delta int) {
+	first := pm.first + delta
+	if first > len(pm.opts)-len(pm.buttons) {
+		first = len(pm.opts) - len(pm.buttons)
+	}
+	if first < 0 {
+		first = 0
+	}
+	if first == pm.first {
+		return
+	}
+	pm.first = first
+	pm.refresh()
+}
+
+// SetCurrent selects option by index and scrolls
+// menu so it is visible.
+func (pm *PopupMenu) SetCurrent(i int) {
+	if i < 0 || i >= len(pm.opts) {
+		return
+	}
+	pm.Current = i
+	if i < pm.first {
+		pm.Scroll(i - pm.first)
+	} else if i >= pm.first+len(pm.buttons) {
+		pm.Scroll(i - pm.first - len(pm.buttons) + 1)
+	}
+	pm.highlight()
+}
+
+// Update buttons to show options starting from first.
+func (pm *PopupMenu) refresh() {
+	for i, bt := range pm.buttons {
+		o := pm.opts[pm.first+i]
+		bt.Text = o.Text
+		bt.Image = o.Image
+		bt.Disabled = o.Disabled || o.Separator
+	}
+	pm.highlight()
+}
+
+// Highlight button of current option.
+func (pm *PopupMenu) highlight() {
+	for i, bt := range pm.buttons {
+		bt.Highlighted = pm.first+i == pm.Current
+	}
+}
+
+// Activate option by index.
+func (pm *PopupMenu) activate(i int) {
+	o := &pm.opts[i]
+	if !o.selectable() {
+		return
+	}
+	if len(o.Submenu) > 0 {
+		pm.openSubmenu(i)
+		return
+	}
+	toggleCheck(pm.opts, i)
+	pm.Surface.PopDownTo(pm)
+	if o.Handler != nil {
+		close := o.Handler(pm)
+		if close {
+			pm.Surface.PopDownTo(nil)
+		}
+	}
+}
+
+// Toggle checkable option, unchecking other options
+// of its group.
+func toggleCheck(opts []MenuOption, i int) {
+	o := &opts[i]
+	if !o.Checkable {
+		return
+	}
+	if o.Group == "" {
+		o.Checked = !o.Checked
+		return
+	}
+	for k := range opts {
+		if opts[k].Group == o.Group {
+			opts[k].Checked = false
+		}
+	}
+	o.Checked = true
+}
+
+// Move keyboard selection by delta skipping disabled options.
+func (pm *PopupMenu) moveCurrent(delta int) {
+	step := 1
+	if delta < 0 {
+		step = -1
+	}
+	i := pm.Current + delta
+	if pm.Current < 0 && delta < 0 {
+		i = len(pm.opts) + delta
+	}
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(pm.opts) {
+		i = len(pm.opts) - 1
+	}
+	for ; i >= 0 && i < len(pm.opts); i += step {
+		if pm.opts[i].selectable() {
+			pm.SetCurrent(i)
+			return
+		}
+	}
+}
+
+// Select option under pointer and open or close submenu.
+func (pm *PopupMenu) hover(i int) {
+	if !pm.opts[i].selectable() {
+		return
+	}
+	pm.SetCurrent(i)
+	if len(pm.opts[i].Submenu) > 0 {
+		pm.openSubmenu(i)
+	} else {
+		pm.closeSubmenu()
+	}
+}
+
+// Open submenu of option next to its button.
+// Returns nil if option isn't visible.
+func (pm *PopupMenu) openSubmenu(i int) *PopupMenu {
+	if pm.child != nil && pm.child.parentIndex == i && pm.Surface.IsPopUp(pm.child) {
+		return pm.child
+	}
+	pm.closeSubmenu()
+	k := i - pm.first
+	if k < 0 || k >= len(pm.buttons) {
+		return nil
+	}
+	theme := pm.MyTheme()
+	ir := pm.buttons[k].GlobalRect()
+	g := pm.GlobalRect()
+	opts := pm.opts[i].Submenu
+	w := menuWidth(pm.Surface, theme, pm.step, opts, pm.Mnemonics)
+	sub := NewPopupMenu(pm.Surface.Root(), Base{
+		Theme: pm.Theme,
+		Rect:  R0(w, pm.step),
+	}, opts...)
+	sub.Mnemonics = pm.Mnemonics
+	// Align the first submenu option with the parent option.
+	sub.PlaceAt(R(g.Min.X, ir.Min.Y, g.Max.X, ir.Max.Y+theme.Pad), SideRight)
+	sub.parentMenu = pm
+	sub.parentIndex = i
+	sub.bar = pm.bar
+	pm.child = sub
+	return sub
+}
+
+// Close submenu (and its submenus) if open.
+func (pm *PopupMenu) closeSubmenu() {
+	if pm.child == nil {
+		return
+	}
+	if pm.Surface.IsPopUp(pm.child) {
+		pm.Surface.PopDownTo(pm)
+	}
+	pm.child = nil
+}
+
+// Returns index of option with mnemonic key just pressed, -1 if none.
+func (pm *PopupMenu) mnemonicAt() int {
+	if !pm.Mnemonics {
+		return -1
+	}
+	for i := range pm.opts {
+		_, _, key := mnemonic(pm.opts[i].Text)
+		if key != KeyUnknown && pm.opts[i].selectable() && pm.Surface.JustPressed(key) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (pm *PopupMenu) onKeys() bool {
+	if !pm.Surface.IsPopUp(pm) {
+		return false
+	}
+	// Only the innermost open menu handles keys.
+	if pm.child != nil && pm.Surface.IsPopUp(pm.child) {
+		return false
+	}
+	s := pm.Surface
+	switch {
+	case s.JustPressed(KeyDown) || s.Repeated(KeyDown):
+		pm.moveCurrent(1)
+	case s.JustPressed(KeyUp) || s.Repeated(KeyUp):
+		pm.moveCurrent(-1)
+	case s.JustPressed(KeyPageDown) || s.Repeated(KeyPageDown):
+		pm.moveCurrent(len(pm.buttons))
+	case s.JustPressed(KeyPageUp) || s.Repeated(KeyPageUp):
+		pm.moveCurrent(-len(pm.buttons))
+	case s.JustPressed(KeyHome):
+		pm.Current = -1
+		pm.moveCurrent(1)
+	case s.JustPressed(KeyEnd):
+		pm.Current = len(pm.opts)
+		pm.moveCurrent(-1)
+	case s.JustPressed(KeyRight):
+		pm.stepRight()
+	case s.JustPressed(KeyLeft):
+		pm.stepLeft()
+	case s.JustPressed(KeyEnter) || s.JustPressed(KeyKPEnter):
+		if pm.Current >= 0 {
+			pm.enter(pm.Current)
+		}
+	default:
+		i := pm.mnemonicAt()
+		if i < 0 {
+			return false
+		}
+		pm.SetCurrent(i)
+		pm.enter(i)
+	}
+	return true
+}
+
+// Open submenu of current option or move to the next
+// menu of menu bar.
+func (pm *PopupMenu) stepRight() {
+	if pm.Current >= 0 && len(pm.opts[pm.Current].Submenu) > 0 {
+		pm.enter(pm.Current)
+	} else if pm.bar != nil {
+		pm.bar.step(1)
+	}
+}
+
+// Close submenu or move to the previous menu of menu bar.
+func (pm *PopupMenu) stepLeft() {
+	if pm.parentMenu != nil {
+		pm.parentMenu.closeSubmenu()
+	} else if pm.bar != nil {
+		pm.bar.step(-1)
+	}
+}
+
+// Activate option from keyboard: submenu is opened
+// with its first option selected.
+func (pm *PopupMenu) enter(i int) {
+	pm.activate(i)
+	if len(pm.opts[i].Submenu) > 0 && pm.child != nil {
+		pm.child.moveCurrent(1)
+	}
+}
+
+// PlaceAt moves menu to preferred side of anchor rect
+// (screen coords), keeping it inside of the surface
+// (see PlaceRect).
+func (pm *PopupMenu) PlaceAt(anchor Rect, sd Side) {
+	r := PlaceRect(pm.Rect.Size(), anchor, pm.Surface.Root().GlobalRect(), sd)
+	if pm.Parent != nil {
+		r = r.Moved(Vec{}.Sub(pm.Parent.GlobalRect().Min))
+	}
+	pm.Rect = r
+}
+
+// Returns distance between options of menus
+// created by widgets (menu bar, context menu).
+func menuStep(s Surface, theme *Theme) float64 {
+	return s.GetTextRect("Wg", theme.TitleFont).H() + theme.Pad*2
+}
+
+// Returns width of popup menu fitting options
+// (with mnemonic markers removed if mnemonics are set).
+func menuWidth(s Surface, theme *Theme, step float64, opts []MenuOption, mnemonics bool) float64 {
+	pad := theme.Pad
+	h := step - pad
+	w := 0.0
+	checks, subs := false, false
+	for _, o := range opts {
+		text := o.Text
+		if mnemonics {
+			text, _, _ = mnemonic(text)
+		}
+		ow := s.GetTextRect(text, theme.TitleFont).W()
+		if o.Image != "" {
+			ow += s.GetImageRect(o.Image).W() + pad
+		}
+		if o.Shortcut != "" {
+			ow += s.GetTextRect(o.Shortcut, theme.TitleFont).W() + pad*2
+		}
+		w = math.Max(w, ow)
+		checks = checks || o.Checkable
+		subs = subs || len(o.Submenu) > 0
+	}
+	w += pad * 4
+	if checks {
+		w += h - pad
+	}
+	if subs {
+		w += h - pad
+	}
+	return math.Ceil(w)
+}
+
+// menuItem is a button showing one option of popup menu.
+type menuItem struct {
+	*PushButton
+	pm *PopupMenu
+	// Index of button in menu.
+	index int
+}
+
+// Paint draws the widget without children.
+func (mi *menuItem) Paint() {
+	pm := mi.pm
+	o := &pm.opts[pm.first+mi.index]
+	r := mi.GlobalRect()
+	theme := mi.MyTheme()
+	if o.Separator {
+		if td, _ := theme.Drawers[ThemeMenuSeparator]; td != nil {
+			y := math.Floor(r.Center().Y)
+			td.Draw(mi.Surface, R(r.Min.X+theme.Pad, y-1, r.Max.X-theme.Pad, y+1), mi.Extras...)
+		}
+		return
+	}
+	td, tcol, disp := mi.look()
+	if td != nil {
+		td.Draw(mi.Surface, r, mi.Extras...)
+	}
+	h := r.H()
+	tr := r
+	if pm.hasChecks {
+		if o.Checkable && o.Checked {
+			key := ThemeMenuCheck
+			if o.Group != "" {
+				key = ThemeMenuRadio
+			}
+			if td, _ := theme.Drawers[key]; td != nil {
+				td.Draw(mi.Surface, R(r.Min.X, r.Min.Y, r.Min.X+h, r.Max.Y).Moved(disp), mi.Extras...)
+			}
+		}
+		tr.Min.X += h - theme.Pad
+	}
+	if pm.hasSubmenus {
+		if len(o.Submenu) > 0 {
+			if td, _ := theme.Drawers[ThemeMenuSubmenu]; td != nil {
+				td.Draw(mi.Surface, R(r.Max.X-h, r.Min.Y, r.Max.X, r.Max.Y).Moved(disp), mi.Extras...)
+			}
+		}
+		tr.Max.X -= h - theme.Pad
+	}
+	if o.Shortcut != "" {
+		mi.DrawImageAndTextIn(tr, "", o.Shortcut, tcol, 0, AlignRight, disp)
+	}
+	if pm.Mnemonics {
+		mi.drawMnemonicText(tr, mi.Image, mi.Text, tcol, disp)
+	} else {
+		mi.DrawImageAndTextIn(tr, mi.Image, mi.Text, tcol, AlignLeft, AlignLeft, disp)
+	}
+}
+
+// Returns text with mnemonic marker removed, index of
+// mnemonic character in resulting text (-1 if none)
+// and key activating it.
+func mnemonic(text string) (string, int, Button) {
+	res := make([]byte, 0, len(text))
+	index := -1
+	key := KeyUnknown
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		if c != '&' || i == len(text)-1 {
+			res = append(res, c)
+			continue
+		}
+		i++
+		c = text[i]
+		if c != '&' && index < 0 {
+			index = len(res)
+			switch {
+			case c >= 'a' && c <= 'z':
+				key = KeyA + Button(c-'a')
+			case c >= 'A' && c <= 'Z':
+				key = KeyA + Button(c-'A')
+			case c >= '0' && c <= '9':
+				key = Key0 + Button(c-'0')
+			}
+		}
+		res = append(res, c)
+	}
+	return string(res), index, key
+}
+
+// Draw left aligned image and text with underlined mnemonic.
+func (p *Panel) drawMnemonicText(r Rect, image, text string, col color.Color, disp Vec) {
+	text, index, _ := mnemonic(text)
+	p.DrawImageAndTextIn(r, image, text, col, AlignLeft, AlignLeft, disp)
+	if index < 0 || col == nil {
+		return
+	}
+	theme := p.MyTheme()
+	s := p.Surface
+	inner := r.Expanded(-theme.Pad)
+	x := inner.Min.X
+	if image != "" {
+		x += s.GetImageRect(image).W() + theme.Pad
+	}
+	x += s.GetTextRect(text[:index], theme.TitleFont).W()
+	w := s.GetTextRect(text[index:index+1], theme.TitleFont).W()
+	y := math.Floor(inner.Center().Y - s.GetTextRect(text, theme.TitleFont).H()/2)
+	s.DrawFillRect(R(x, y, x+w, y+1).Moved(disp), col)
+}
@@ -0,0 +1,124 @@
+package grue
+
+import "fmt"
+
+// ProgressBar shows value in range as partially filled bar.
+// It can be used for loading screens as well as
+// for health/mana bars (see Segments).
+type ProgressBar struct {
+	*Panel
+	Min   float64
+	Max   float64
+	Value float64
+
+	Orientation Orientation
+	// ShowText draws value over the bar. If Text is set,
+	// it's shown as is, otherwise TextFormat is used.
+	ShowText bool
+	// TextFormat is formatted with percentage of the value.
+	// Default is "%.0f%%".
+	TextFormat string
+
+	// Indeterminate bar shows a chunk moving back and forth
+	// instead of value, e.g. while total amount of work
+	// is unknown.
+	Indeterminate bool
+	// Period of indeterminate bar animation in seconds.
+	Period float64
+
+	// Segments splits bar into given number of
+	// separately drawn segments if non-zero.
+	Segments   int
+	SegmentGap float64
+}
+
+// NewProgressBar creates new progress bar with range 0..100.
+func NewProgressBar(parent Widget, b Base) *ProgressBar {
+	pb := &ProgressBar{
+		Panel:      NewPanel(nil, b),
+		Max:        100,
+		TextFormat: "%.0f%%",
+		Period:     2,
+		SegmentGap: 2,
+	}
+	InitWidget(parent, pb)
+	return pb
+}
+
+// Fraction returns value position in range from 0 to 1.
+func (pb *ProgressBar) Fraction() float64 {
+	if pb.Max <= pb.Min {
+		return 0
+	}
+	f := (pb.Value - pb.Min) / (pb.Max - pb.Min)
+	if f < 0 {
+		return 0
+	}
+	if f > 1 {
+		return 1
+	}
+	return f
+}
+
+// Return part of rect between from and to (0..1)
+// along bar orientation.
+func (pb *ProgressBar) part(r Rect, from, to float64) Rect {
+	if pb.Orientation == Vertical {
+		return R(r.Min.X, r.Min.Y+r.H()*from, r.Max.X, r.Min.Y+r.H()*to)
+	}
+	return R(r.Min.X+r.W()*from, r.Min.Y, r.Min.X+r.W()*to, r.Max.Y)
+}
+
+// Paint draws the widget without children.
+func (pb *ProgressBar) Paint() {
+	r := pb.GlobalRect()
+	theme := pb.MyTheme()
+	if td, _ := theme.Drawers[ThemeProgressGroove]; td != nil {
+		td.Draw(pb.Surface, r, pb.Extras...)
+	}
+	in := r.Expanded(-theme.Pad / 2)
+	fill, _ := theme.Drawers[ThemeProgressFill]
+	f := pb.Fraction()
+	switch {
+	case fill == nil || pb.IsDisabled():
+	case pb.Indeterminate:
+		const chunk = 0.25
+		pos := pb.Surface.Pulse(pb.Period) * (1 - chunk)
+		fill.Draw(pb.Surface, pb.part(in, pos, pos+chunk), pb.Extras...)
+	case pb.Segments > 0:
+		n := float64(pb.Segments)
+		l := in.W()
+		if pb.Orientation == Vertical {
+			l = in.H()
+		}
+		// Segment length and gap relative to bar length.
+		gap := pb.SegmentGap / l
+		seg := (1 - gap*(n-1)) / n
+		for k := 0.0; k < n && f*n > k; k++ {
+			from := k * (seg + gap)
+			to := from + seg
+			if f*n < k+1 {
+				to = from + seg*(f*n-k)
+			}
+			fill.Draw(pb.Surface, pb.part(in, from, to), pb.Extras...)
+		}
+	case f > 0:
+		fill.Draw(pb.Surface, pb.part(in, 0, f), pb.Extras...)
+	}
+
+	if !pb.ShowText || pb.Indeterminate && pb.Text == "" {
+		return
+	}
+	text := pb.Text
+	if text == "" {
+		text = fmt.Sprintf(pb.TextFormat, f*100)
+	}
+	tcol := theme.PanelTextColor
+	if tcol == nil {
+		tcol = theme.TextColor
+	}
+	if pb.IsDisabled() {
+		tcol = theme.DisabledTextColor
+	}
+	pb.DrawImageAndTextIn(r, "", text, tcol, 0, AlignCenter, Vec{})
+}
@@ -1,9 +1,18 @@
 package grue
 
-// PushButton is pressable and optionally, checkable (TODO), button.
+import "image/color"
+
+// PushButton is pressable and optionally, checkable, button.
 type PushButton struct {
 	*Panel
 	Pressed bool
+	// Highlighted forces highlighted look regardless
+	// of pointer (e.g. for keyboard selection in menus).
+	Highlighted bool
+	// Checkable button is toggled when pressed.
+	// Checked button looks like pressed one.
+	Checkable bool
+	Checked   bool
 
 	OnPress func()
 }
@@ -14,6 +23,7 @@ func NewPushButton(parent Widget, b Base) *PushButton {
 		Panel: NewPanel(nil, b),
 	}
 	InitWidget(parent, pb)
+	pb.FocusPolicy = FocusStrong
 
 	pb.OnMouseOut = func() {
 		pb.Pressed = false
@@ -29,16 +39,47 @@ func NewPushButton(parent Widget, b Base) *PushButton {
 			return
 		}
 		pb.Pressed = false
-		if pb.OnPress != nil {
-			pb.OnPress()
-		}
+		pb.Press()
 	}
+	pb.OnKeys = pb.onKeys
 	return pb
 }
 
+// Enter or Space presses focused button.
+func (pb *PushButton) onKeys() bool {
+	s := pb.Surface
+	if !pb.Equals(s.Focus()) {
+		return false
+	}
+	if s.JustPressed(KeyEnter) || s.JustPressed(KeyKPEnter) || s.JustPressed(KeySpace) {
+		pb.Press()
+		return true
+	}
+	return false
+}
+
+// Press toggles checkable button and calls OnPress.
+func (pb *PushButton) Press() {
+	if pb.Checkable {
+		pb.Checked = !pb.Checked
+	}
+	if pb.OnPress != nil {
+		pb.OnPress()
+	}
+}
+
 // Paint draws the widget without children.
 func (pb *PushButton) Paint() {
-	r := pb.GlobalRect()
+	td, tcol, disp := pb.look()
+	if td != nil {
+		td.Draw(pb.Surface, pb.GlobalRect(), pb.Extras...)
+	}
+	pb.DrawImageAndText(pb.Image, pb.Text, tcol, pb.ImageAlign, pb.TextAlign, disp)
+}
+
+// Returns drawer, text color and text displacement
+// for current button state.
+func (pb *PushButton) look() (ThemeDrawer, color.Color, Vec) {
 	theme := pb.MyTheme()
 	tdef, _ := theme.Drawers[ThemeButton]
 	var tcur ThemeDrawer
@@ -48,20 +89,19 @@ func (pb *PushButton) Paint() {
 	}
 	var disp Vec
 	switch {
-	case pb.Disabled:
+	case pb.IsDisabled():
 		tcur, _ = theme.Drawers[ThemeButtonDisabled]
 		tcol = theme.DisabledTextColor
 	case pb.Pressed:
 		tcur, _ = theme.Drawers[ThemeButtonActive]
 		disp = theme.PressDisplace
-	case pb.PointerInside:
+	case pb.Checked:
+		tcur, _ = theme.Drawers[ThemeButtonActive]
+	case pb.PointerInside || pb.Highlighted:
 		tcur, _ = theme.Drawers[ThemeButtonHL]
 	}
 	if tcur != nil {
 		tdef = tcur
 	}
-	if tdef != nil {
-		tdef.Draw(pb.Surface, r, pb.Extras...)
-	}
-	pb.DrawImageAndText(pb.Image, pb.Text, tcol, pb.ImageAlign, pb.TextAlign, disp)
+	return tdef, tcol, disp
 }
@@ -0,0 +1,332 @@
+package grue
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Shortcut is a key chord: key pressed while
+// modifier keys are held (e.g. Ctrl+S).
+type Shortcut struct {
+	Key  Button
+	Mods Modifier
+}
+
+// Names of modifiers in the order they are written.
+var modifierNames = []struct {
+	mod   Modifier
+	names []string
+}{
+	{ModControl, []string{"Ctrl", "Control"}},
+	{ModShift, []string{"Shift"}},
+	{ModAlt, []string{"Alt", "Option"}},
+	{ModSuper, []string{"Super", "Cmd", "Meta", "Win"}},
+}
+
+// Names of keys other than letters, digits and
+// function keys. The first name is canonical.
+var keyNames = []struct {
+	key   Button
+	names []string
+}{
+	{KeyEnter, []string{"Enter", "Return"}},
+	{KeyKPEnter, []string{"KPEnter"}},
+	{KeyEscape, []string{"Esc", "Escape"}},
+	{KeyTab, []string{"Tab"}},
+	{KeySpace, []string{"Space"}},
+	{KeyBackspace, []string{"Backspace"}},
+	{KeyInsert, []string{"Ins", "Insert"}},
+	{KeyDelete, []string{"Del", "Delete"}},
+	{KeyHome, []string{"Home"}},
+	{KeyEnd, []string{"End"}},
+	{KeyPageUp, []string{"PgUp", "PageUp"}},
+	{KeyPageDown, []string{"PgDown", "PgDn", "PageDown"}},
+	{KeyLeft, []string{"Left"}},
+	{KeyRight, []string{"Right"}},
+	{KeyUp, []string{"Up"}},
+	{KeyDown, []string{"Down"}},
+	{KeyPause, []string{"Pause"}},
+	{KeyPrintScreen, []string{"PrintScreen"}},
+	{KeyMenu, []string{"Menu"}},
+	{KeyMinus, []string{"-", "Minus"}},
+	{KeyEqual, []string{"=", "Equal"}},
+	{KeyComma, []string{","}},
+	{KeyPeriod, []string{"."}},
+	{KeySlash, []string{"/"}},
+	{KeySemicolon, []string{";"}},
+	{KeyApostrophe, []string{"'"}},
+	{KeyLeftBracket, []string{"["}},
+	{KeyRightBracket, []string{"]"}},
+	{KeyBackslash, []string{"\\"}},
+	{KeyGraveAccent, []string{"`"}},
+	{KeyKPAdd, []string{"KPAdd"}},
+	{KeyKPSubtract, []string{"KPSubtract"}},
+	{KeyKPMultiply, []string{"KPMultiply"}},
+	{KeyKPDivide, []string{"KPDivide"}},
+}
+
+// ParseShortcut parses shortcut text like "Ctrl+S", "Ctrl+Shift+Z"
+// or "F5". Names are case insensitive.
+func ParseShortcut(text string) (Shortcut, error) {
+	var sc Shortcut
+	parts := strings.Split(text, "+")
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		if i < len(parts)-1 {
+			mod := parseModifier(part)
+			if mod == 0 {
+				return Shortcut{}, fmt.Errorf("invalid shortcut %q: unknown modifier %q", text, part)
+			}
+			sc.Mods |= mod
+			continue
+		}
+		key, ok := parseKey(part)
+		if !ok {
+			return Shortcut{}, fmt.Errorf("invalid shortcut %q: unknown key %q", text, part)
+		}
+		sc.Key = key
+	}
+	return sc, nil
+}
+
+func parseModifier(name string) Modifier {
+	for _, mn := range modifierNames {
+		for _, n := range mn.names {
+			if strings.EqualFold(n, name) {
+				return mn.mod
+			}
+		}
+	}
+	return 0
+}
+
+func parseKey(name string) (Button, bool) {
+	if len(name) == 1 {
+		c := strings.ToUpper(name)[0]
+		switch {
+		case c >= 'A' && c <= 'Z':
+			return KeyA + Button(c-'A'), true
+		case c >= '0' && c <= '9':
+			return Key0 + Button(c-'0'), true
+		}
+	}
+	if len(name) > 1 && (name[0] == 'F' || name[0] == 'f') {
+		if n, err := strconv.Atoi(name[1:]); err == nil && n >= 1 && n <= 25 {
+			return KeyF1 + Button(n-1), true
+		}
+	}
+	for _, kn := range keyNames {
+		for _, n := range kn.names {
+			if strings.EqualFold(n, name) {
+				return kn.key, true
+			}
+		}
+	}
+	return KeyUnknown, false
+}
+
+// String returns shortcut text in form accepted by ParseShortcut.
+func (sc Shortcut) String() string {
+	var b strings.Builder
+	for _, mn := range modifierNames {
+		if sc.Mods&mn.mod != 0 {
+			b.WriteString(mn.names[0])
+			b.WriteString("+")
+		}
+	}
+	switch {
+	case sc.Key >= KeyA && sc.Key <= KeyZ:
+		b.WriteByte(byte('A' + sc.Key - KeyA))
+	case sc.Key >= Key0 && sc.Key <= Key9:
+		b.WriteByte(byte('0' + sc.Key - Key0))
+	case sc.Key >= KeyF1 && sc.Key <= KeyF25:
+		b.WriteString("F" + strconv.Itoa(int(sc.Key-KeyF1+1)))
+	default:
+		name := "?"
+		for _, kn := range keyNames {
+			if kn.key == sc.Key {
+				name = kn.names[0]
+				break
+			}
+		}
+		b.WriteString(name)
+	}
+	return b.String()
+}
+
+// ShortcutScope defines when shortcut works.
+type ShortcutScope int
+
+// Shortcut scopes.
+const (
+	// ShortcutGlobal works always, even if popups
+	// or modal widgets are open.
+	ShortcutGlobal ShortcutScope = iota
+	// ShortcutWindow works while its widget is visible,
+	// enabled, not blocked by modal widget and no popups
+	// are open.
+	ShortcutWindow
+	// ShortcutFocused works while focus is within subtree
+	// of its widget (that is enabled).
+	ShortcutFocused
+)
+
+// ShortcutBinding binds shortcut to handler.
+type ShortcutBinding struct {
+	Shortcut Shortcut
+	Scope    ShortcutScope
+	// Widget is owner of window or focused scope
+	// binding. It's ignored for global scope.
+	Widget Widget
+	// Handler is called when shortcut is pressed.
+	// If it returns false, key is passed further.
+	Handler func() bool
+}
+
+// Shortcuts is registry of keyboard shortcuts.
+// Bindings of focused scope have priority (the one
+// closest to focused widget wins), then window scope,
+// then global.
+type Shortcuts struct {
+	bindings []*ShortcutBinding
+}
+
+// NewShortcuts creates empty registry.
+func NewShortcuts() *Shortcuts {
+	return &Shortcuts{}
+}
+
+// Add registers binding. It returns error without adding
+// binding if it conflicts with existing one: i.e. has the
+// same shortcut and overlapping scope (either binding is
+// global or owner widget of one is ancestor of the other's).
+func (sr *Shortcuts) Add(b *ShortcutBinding) error {
+	if b.Scope != ShortcutGlobal && b.Widget == nil {
+		return fmt.Errorf("shortcut %v requires widget for its scope", b.Shortcut)
+	}
+	if c := sr.conflict(b); c != nil {
+		return fmt.Errorf("shortcut %v conflicts with already registered one", b.Shortcut)
+	}
+	sr.bindings = append(sr.bindings, b)
+	return nil
+}
+
+// AddText parses shortcut text and registers handler for it.
+func (sr *Shortcuts) AddText(text string, scope ShortcutScope, w Widget, handler func() bool) (*ShortcutBinding, error) {
+	sc, err := ParseShortcut(text)
+	if err != nil {
+		return nil, err
+	}
+	b := &ShortcutBinding{Shortcut: sc, Scope: scope, Widget: w, Handler: handler}
+	if err := sr.Add(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Returns existing binding conflicting with b or nil.
+func (sr *Shortcuts) conflict(b *ShortcutBinding) *ShortcutBinding {
+	for _, c := range sr.bindings {
+		if c.Shortcut != b.Shortcut {
+			continue
+		}
+		if c.Scope == ShortcutGlobal || b.Scope == ShortcutGlobal ||
+			c.Widget.GetPanel().IsAncestorOf(b.Widget) ||
+			b.Widget.GetPanel().IsAncestorOf(c.Widget) {
+			return c
+		}
+	}
+	return nil
+}
+
+// Remove unregisters binding.
+func (sr *Shortcuts) Remove(b *ShortcutBinding) {
+	for i, c := range sr.bindings {
+		if c == b {
+			sr.bindings = append(sr.bindings[:i], sr.bindings[i+1:]...)
+			return
+		}
+	}
+}
+
+// RemoveWidget unregisters all bindings owned by widget.
+func (sr *Shortcuts) RemoveWidget(w Widget) {
+	rest := sr.bindings[:0]
+	for _, c := range sr.bindings {
+		if c.Scope == ShortcutGlobal || !c.Widget.Equals(w) {
+			rest = append(rest, c)
+		}
+	}
+	sr.bindings = rest
+}
+
+// Find returns all bindings of shortcut.
+func (sr *Shortcuts) Find(sc Shortcut) []*ShortcutBinding {
+	var found []*ShortcutBinding
+	for _, c := range sr.bindings {
+		if c.Shortcut == sc {
+			found = append(found, c)
+		}
+	}
+	return found
+}
+
+// ProcessShortcuts calls handler of shortcut just pressed
+// on surface, if any is active. roots are widgets receiving
+// input (root or modal widget and popups outside of it).
+// Backends call it before keyboard processing.
+// Returns true if key is consumed.
+func ProcessShortcuts(s Surface, roots []Widget) bool {
+	sr := s.Shortcuts()
+	if len(sr.bindings) == 0 {
+		return false
+	}
+	mods := s.Modifiers()
+	var pressed []*ShortcutBinding
+	for _, b := range sr.bindings {
+		if b.Shortcut.Mods == mods && s.JustPressed(b.Shortcut.Key) {
+			pressed = append(pressed, b)
+		}
+	}
+	if len(pressed) == 0 {
+		return false
+	}
+	under := func(w Widget) bool {
+		for _, r := range roots {
+			if r.GetPanel().IsAncestorOf(w) {
+				return true
+			}
+		}
+		return false
+	}
+	call := func(b *ShortcutBinding) bool {
+		return b.Handler != nil && b.Handler()
+	}
+
+	// Focused scope: from focused widget up to parents.
+	if f := s.Focus(); f != nil && under(f) && f.GetPanel().IsVisible() {
+		for w := f; w != nil; w = w.GetPanel().Parent {
+			for _, b := range pressed {
+				if b.Scope == ShortcutFocused && b.Widget.Equals(w) &&
+					!w.GetPanel().IsDisabled() && call(b) {
+					return true
+				}
+			}
+		}
+	}
+	if !s.IsPopUpMode() {
+		for _, b := range pressed {
+			if b.Scope == ShortcutWindow && under(b.Widget) &&
+				b.Widget.GetPanel().IsVisible() && !b.Widget.GetPanel().IsDisabled() && call(b) {
+				return true
+			}
+		}
+	}
+	for _, b := range pressed {
+		if b.Scope == ShortcutGlobal && call(b) {
+			return true
+		}
+	}
+	return false
+}
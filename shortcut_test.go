@@ -0,0 +1,100 @@
+package grue
+
+import "testing"
+
+func TestParseShortcut(t *testing.T) {
+	tests := []struct {
+		text string
+		want Shortcut
+		str  string
+	}{
+		{"Ctrl+S", Shortcut{KeyS, ModControl}, "Ctrl+S"},
+		{"ctrl+shift+z", Shortcut{KeyZ, ModControl | ModShift}, "Ctrl+Shift+Z"},
+		{"Shift+Control+Z", Shortcut{KeyZ, ModControl | ModShift}, "Ctrl+Shift+Z"},
+		{"F5", Shortcut{KeyF5, 0}, "F5"},
+		{"Alt+F12", Shortcut{KeyF12, ModAlt}, "Alt+F12"},
+		{"Cmd+1", Shortcut{Key1, ModSuper}, "Super+1"},
+		{" Ctrl + Return ", Shortcut{KeyEnter, ModControl}, "Ctrl+Enter"},
+		{"PgDn", Shortcut{KeyPageDown, 0}, "PgDown"},
+		{"Ctrl+-", Shortcut{KeyMinus, ModControl}, "Ctrl+-"},
+		{"esc", Shortcut{KeyEscape, 0}, "Esc"},
+	}
+	for _, tt := range tests {
+		sc, err := ParseShortcut(tt.text)
+		if err != nil {
+			t.Errorf("ParseShortcut(%q): unexpected error %v", tt.text, err)
+			continue
+		}
+		if sc != tt.want {
+			t.Errorf("ParseShortcut(%q) = %+v, want %+v", tt.text, sc, tt.want)
+		}
+		if s := sc.String(); s != tt.str {
+			t.Errorf("ParseShortcut(%q).String() = %q, want %q", tt.text, s, tt.str)
+		}
+		// String is accepted by ParseShortcut.
+		if back, err := ParseShortcut(sc.String()); err != nil || back != sc {
+			t.Errorf("ParseShortcut(%q) = %+v, %v, want %+v", sc.String(), back, err, sc)
+		}
+	}
+}
+
+func TestParseShortcutInvalid(t *testing.T) {
+	for _, text := range []string{
+		"",
+		"Ctrl+",
+		"Hyper+S",
+		"Ctrl+Foo",
+		"F0",
+		"F26",
+		"S+Ctrl",
+		"Ctrl++",
+	} {
+		if sc, err := ParseShortcut(text); err == nil {
+			t.Errorf("ParseShortcut(%q) = %+v, want error", text, sc)
+		}
+	}
+}
+
+func TestShortcutsConflict(t *testing.T) {
+	root := NewPanel(nil, Base{})
+	outer := NewPanel(root, Base{})
+	inner := NewPanel(outer, Base{})
+	other := NewPanel(root, Base{})
+	sc := Shortcut{KeyS, ModControl}
+	tests := []struct {
+		name     string
+		existing ShortcutBinding
+		added    ShortcutBinding
+		conflict bool
+	}{
+		{"same widget", ShortcutBinding{Scope: ShortcutWindow, Widget: outer},
+			ShortcutBinding{Scope: ShortcutWindow, Widget: outer}, true},
+		{"global and window", ShortcutBinding{Scope: ShortcutGlobal},
+			ShortcutBinding{Scope: ShortcutWindow, Widget: outer}, true},
+		{"focused and global", ShortcutBinding{Scope: ShortcutFocused, Widget: inner},
+			ShortcutBinding{Scope: ShortcutGlobal}, true},
+		{"ancestor", ShortcutBinding{Scope: ShortcutWindow, Widget: outer},
+			ShortcutBinding{Scope: ShortcutFocused, Widget: inner}, true},
+		{"descendant", ShortcutBinding{Scope: ShortcutFocused, Widget: inner},
+			ShortcutBinding{Scope: ShortcutWindow, Widget: outer}, true},
+		{"siblings", ShortcutBinding{Scope: ShortcutWindow, Widget: outer},
+			ShortcutBinding{Scope: ShortcutWindow, Widget: other}, false},
+	}
+	for _, tt := range tests {
+		sr := NewShortcuts()
+		tt.existing.Shortcut, tt.added.Shortcut = sc, sc
+		if err := sr.Add(&tt.existing); err != nil {
+			t.Fatalf("%s: unexpected error %v", tt.name, err)
+		}
+		err := sr.Add(&tt.added)
+		if (err != nil) != tt.conflict {
+			t.Errorf("%s: Add error = %v, want conflict %v", tt.name, err, tt.conflict)
+		}
+		// Different chord never conflicts.
+		b := tt.added
+		b.Shortcut.Key = KeyD
+		if err := sr.Add(&b); err != nil {
+			t.Errorf("%s: Add of other chord: unexpected error %v", tt.name, err)
+		}
+	}
+}
@@ -0,0 +1,344 @@
+package grue
+
+import "math"
+
+// Default thickness of splitter handles (pixels).
+const splitterHandleSize = 6
+
+// SplitPane is one pane of splitter.
+type SplitPane struct {
+	// Panel to put pane widgets to. It's sized by splitter.
+	Panel *Panel
+	// Size of pane along splitter orientation.
+	Size float64
+	// MinSize limits resizing by dragging handles.
+	MinSize float64
+	// Collapsible pane is collapsed or restored by
+	// double click on adjacent handle.
+	Collapsible bool
+	Collapsed   bool
+}
+
+// SplitterState holds sizes of splitter panes
+// to save and restore layout.
+type SplitterState struct {
+	Sizes     []float64 `json:"sizes"`
+	Collapsed []bool    `json:"collapsed"`
+}
+
+// Splitter arranges panes horizontally (left to right)
+// or vertically (top to bottom), separated by handles
+// which can be dragged to resize adjacent panes.
+// Collapsed panes are hidden.
+type Splitter struct {
+	*Panel
+	Orientation Orientation
+	Panes       []*SplitPane
+	// HandleSize is thickness of handles. If zero, 6 is used.
+	HandleSize float64
+	// Stretch is index of pane that takes extra space when
+	// splitter size changes, -1 for the last visible pane.
+	Stretch int
+
+	// OnResize is called when panes are resized by user.
+	OnResize func()
+
+	// Handle rectangles (relative to splitter).
+	handles []Rect
+	// Index of handle being dragged, -1 if none.
+	drag      int
+	dragPos   float64
+	dragSizes [2]float64
+
+	lastClickTime   float64
+	lastClickHandle int
+}
+
+// NewSplitter creates new splitter without panes.
+func NewSplitter(parent Widget, b Base, o Orientation) *Splitter {
+	sp := &Splitter{
+		Panel:           NewPanel(nil, b),
+		Orientation:     o,
+		Stretch:         -1,
+		drag:            -1,
+		lastClickHandle: -1,
+	}
+	InitWidget(parent, sp)
+	sp.OnMouseDown = sp.onMouseDown
+	sp.OnMouseUp = func(bt Button) {
+		if bt == MouseButtonLeft {
+			sp.drag = -1
+		}
+	}
+	return sp
+}
+
+// AddPane adds new pane of given size at the end.
+func (sp *Splitter) AddPane(size, minSize float64) *SplitPane {
+	p := &SplitPane{
+		Panel:       NewPanel(sp, Base{}),
+		Size:        size,
+		MinSize:     minSize,
+		Collapsible: true,
+	}
+	sp.Panes = append(sp.Panes, p)
+	sp.Layout()
+	return p
+}
+
+// SetCollapsed collapses or restores pane by index.
+func (sp *Splitter) SetCollapsed(i int, collapsed bool) {
+	if i < 0 || i >= len(sp.Panes) || sp.Panes[i].Collapsed == collapsed {
+		return
+	}
+	p := sp.Panes[i]
+	p.Collapsed = collapsed
+	p.Panel.SetHidden(collapsed)
+	sp.Layout()
+}
+
+// State returns pane sizes to be saved.
+func (sp *Splitter) State() SplitterState {
+	st := SplitterState{
+		Sizes:     make([]float64, len(sp.Panes)),
+		Collapsed: make([]bool, len(sp.Panes)),
+	}
+	for i, p := range sp.Panes {
+		st.Sizes[i] = p.Size
+		st.Collapsed[i] = p.Collapsed
+	}
+	return st
+}
+
+// SetState restores pane sizes saved by State.
+// Extra values are ignored.
+func (sp *Splitter) SetState(st SplitterState) {
+	for i, p := range sp.Panes {
+		if i < len(st.Sizes) {
+			p.Size = math.Max(st.Sizes[i], p.MinSize)
+		}
+		if i < len(st.Collapsed) {
+			sp.SetCollapsed(i, st.Collapsed[i])
+		}
+	}
+	sp.Layout()
+}
+
+// Layout fits panes into splitter. Splitter does it
+// every frame, call it to have pane rects updated
+// right after changing sizes.
+func (sp *Splitter) Layout() {
+	sp.handles = sp.handles[:0]
+	if len(sp.Panes) == 0 {
+		return
+	}
+	hs := sp.handleSize()
+	total := 0.0
+	for _, p := range sp.Panes {
+		if !p.Collapsed {
+			total += p.Size
+		}
+	}
+	over := total - (sp.length() - hs*float64(len(sp.Panes)-1))
+	if st := sp.stretchPane(); st >= 0 {
+		p := sp.Panes[st]
+		d := math.Max(p.Size-over, p.MinSize) - p.Size
+		p.Size += d
+		over += d
+	}
+	// Not enough space: shrink panes starting from the last one.
+	for i := len(sp.Panes) - 1; i >= 0 && over > 0; i-- {
+		p := sp.Panes[i]
+		if p.Collapsed {
+			continue
+		}
+		d := math.Min(over, p.Size-p.MinSize)
+		if d > 0 {
+			p.Size -= d
+			over -= d
+		}
+	}
+
+	pos := 0.0
+	for i, p := range sp.Panes {
+		if !p.Collapsed {
+			p.Panel.Rect = sp.span(pos, p.Size)
+			pos += p.Size
+		}
+		if i < len(sp.Panes)-1 {
+			sp.handles = append(sp.handles, sp.span(pos, hs))
+			pos += hs
+		}
+	}
+}
+
+// Returns rectangle (relative to splitter) at given
+// distance from the start of splitter and of given size.
+func (sp *Splitter) span(pos, size float64) Rect {
+	if sp.Orientation == Vertical {
+		h := sp.Rect.H()
+		return R(0, h-pos-size, sp.Rect.W(), h-pos)
+	}
+	return R(pos, 0, pos+size, sp.Rect.H())
+}
+
+// Returns position along splitter orientation.
+func (sp *Splitter) axis(v Vec) float64 {
+	if sp.Orientation == Vertical {
+		return -v.Y
+	}
+	return v.X
+}
+
+func (sp *Splitter) length() float64 {
+	if sp.Orientation == Vertical {
+		return sp.Rect.H()
+	}
+	return sp.Rect.W()
+}
+
+func (sp *Splitter) handleSize() float64 {
+	if sp.HandleSize > 0 {
+		return sp.HandleSize
+	}
+	return splitterHandleSize
+}
+
+// Returns index of pane taking extra space, -1 if all collapsed.
+func (sp *Splitter) stretchPane() int {
+	if sp.Stretch >= 0 && sp.Stretch < len(sp.Panes) && !sp.Panes[sp.Stretch].Collapsed {
+		return sp.Stretch
+	}
+	for i := len(sp.Panes) - 1; i >= 0; i-- {
+		if !sp.Panes[i].Collapsed {
+			return i
+		}
+	}
+	return -1
+}
+
+// HandleAt returns index of handle at given position
+// (screen coords), -1 if there is none. Handle i
+// separates panes i and i+1.
+func (sp *Splitter) HandleAt(pos Vec) int {
+	pos = pos.Sub(sp.GlobalRect().Min)
+	for i, r := range sp.handles {
+		if r.Contains(pos) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (sp *Splitter) onMouseDown(bt Button) {
+	if bt != MouseButtonLeft || sp.IsDisabled() {
+		return
+	}
+	pos := sp.Surface.MousePos()
+	i := sp.HandleAt(pos)
+	if i < 0 {
+		return
+	}
+	now := sp.Surface.TotalTime()
+	if i == sp.lastClickHandle && now-sp.lastClickTime < doubleClickTime {
+		sp.lastClickHandle = -1
+		sp.toggleCollapsed(i)
+		return
+	}
+	sp.lastClickHandle = i
+	sp.lastClickTime = now
+	a, b := sp.Panes[i], sp.Panes[i+1]
+	if a.Collapsed || b.Collapsed {
+		return
+	}
+	sp.drag = i
+	sp.dragPos = sp.axis(pos)
+	sp.dragSizes = [2]float64{a.Size, b.Size}
+}
+
+// Collapse or restore one of the panes adjacent to handle.
+// Collapsed pane is restored; otherwise the pane farther
+// from the stretch pane is collapsed.
+func (sp *Splitter) toggleCollapsed(h int) {
+	for _, i := range []int{h, h + 1} {
+		if sp.Panes[i].Collapsed {
+			sp.SetCollapsed(i, false)
+			sp.resized()
+			return
+		}
+	}
+	st := sp.stretchPane()
+	order := []int{h + 1, h}
+	if st > h {
+		order = []int{h, h + 1}
+	}
+	for _, i := range order {
+		if sp.Panes[i].Collapsible && i != st {
+			sp.SetCollapsed(i, true)
+			sp.resized()
+			return
+		}
+	}
+}
+
+func (sp *Splitter) dragTo(pos Vec) {
+	a, b := sp.Panes[sp.drag], sp.Panes[sp.drag+1]
+	d := sp.axis(pos) - sp.dragPos
+	sa, sb := sp.dragSizes[0]+d, sp.dragSizes[1]-d
+	if sa < a.MinSize {
+		sb -= a.MinSize - sa
+		sa = a.MinSize
+	}
+	if sb < b.MinSize {
+		sa -= b.MinSize - sb
+		sb = b.MinSize
+	}
+	if sa == a.Size && sb == b.Size {
+		return
+	}
+	a.Size, b.Size = sa, sb
+	sp.Layout()
+	sp.resized()
+}
+
+func (sp *Splitter) resized() {
+	if sp.OnResize != nil {
+		sp.OnResize()
+	}
+}
+
+// ProcessMouse generates mouse events. Besides Panel
+// processing, it tracks dragging of handle even if
+// pointer leaves it.
+func (sp *Splitter) ProcessMouse(wu Widget) {
+	if sp.drag >= 0 {
+		if sp.Surface.Pressed(MouseButtonLeft) {
+			sp.dragTo(sp.Surface.MousePos())
+		} else {
+			sp.drag = -1
+		}
+	}
+	sp.Panel.ProcessMouse(wu)
+}
+
+// Paint draws the widget without children.
+func (sp *Splitter) Paint() {
+	sp.Layout()
+	theme := sp.MyTheme()
+	hover := -1
+	if sp.PointerInside && !sp.IsDisabled() {
+		hover = sp.HandleAt(sp.Surface.MousePos())
+	}
+	min := sp.GlobalRect().Min
+	for i, r := range sp.handles {
+		td, _ := theme.Drawers[ThemeSplitterHandle]
+		if i == hover || i == sp.drag {
+			if tcur, _ := theme.Drawers[ThemeSplitterHandleHL]; tcur != nil {
+				td = tcur
+			}
+		}
+		if td != nil {
+			td.Draw(sp.Surface, r.Moved(min), sp.Extras...)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package grue
+
+// StatusSection is one section of status bar.
+type StatusSection struct {
+	Text  string
+	Image string
+	// Width of section. Sections with zero width
+	// share space left from other sections.
+	Width     float64
+	TextAlign Align
+	Disabled  bool
+}
+
+// StatusBar is a bar divided into sections showing
+// text and/or image, usually at the bottom of window.
+type StatusBar struct {
+	*Panel
+	Sections []*StatusSection
+}
+
+// NewStatusBar creates new status bar without sections.
+func NewStatusBar(parent Widget, b Base) *StatusBar {
+	sb := &StatusBar{
+		Panel: NewPanel(nil, b),
+	}
+	InitWidget(parent, sb)
+	return sb
+}
+
+// AddSection adds new section of given width
+// (0 to take free space) at the right end.
+func (sb *StatusBar) AddSection(width float64) *StatusSection {
+	sec := &StatusSection{
+		Width:     width,
+		TextAlign: AlignLeft,
+	}
+	sb.Sections = append(sb.Sections, sec)
+	return sec
+}
+
+// SectionRects returns rectangles of sections (screen coords).
+func (sb *StatusBar) SectionRects() []Rect {
+	r := sb.GlobalRect()
+	fixed := 0.0
+	flex := 0
+	for _, sec := range sb.Sections {
+		fixed += sec.Width
+		if sec.Width == 0 {
+			flex++
+		}
+	}
+	fw := 0.0
+	if flex > 0 && fixed < r.W() {
+		fw = (r.W() - fixed) / float64(flex)
+	}
+	rects := make([]Rect, len(sb.Sections))
+	x := r.Min.X
+	for i, sec := range sb.Sections {
+		w := sec.Width
+		if w == 0 {
+			w = fw
+		}
+		rects[i] = R(x, r.Min.Y, x+w, r.Max.Y)
+		x += w
+	}
+	return rects
+}
+
+// SectionAt returns index of section at given position
+// (screen coords), -1 if there is none.
+func (sb *StatusBar) SectionAt(pos Vec) int {
+	for i, r := range sb.SectionRects() {
+		if r.Contains(pos) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Paint draws the widget without children.
+func (sb *StatusBar) Paint() {
+	theme := sb.MyTheme()
+	if td, _ := theme.Drawers[ThemeStatusBar]; td != nil {
+		td.Draw(sb.Surface, sb.GlobalRect(), sb.Extras...)
+	}
+	textColor := theme.PanelTextColor
+	if textColor == nil {
+		textColor = theme.TextColor
+	}
+	td, _ := theme.Drawers[ThemeStatusSection]
+	for i, r := range sb.SectionRects() {
+		sec := sb.Sections[i]
+		if td != nil {
+			td.Draw(sb.Surface, r, sb.Extras...)
+		}
+		tcol := textColor
+		if sb.IsDisabled() || sec.Disabled {
+			tcol = theme.DisabledTextColor
+		}
+		sb.DrawImageAndTextIn(r, sec.Image, sec.Text, tcol, AlignLeft, sec.TextAlign, Vec{})
+	}
+}
@@ -0,0 +1,277 @@
+package grue
+
+import (
+	"image/color"
+	"math"
+)
+
+// TableCell is content of table cell.
+type TableCell struct {
+	Text  string
+	Image string
+	// Paint, if set, draws cell content instead of text and image.
+	Paint func(s Surface, r Rect)
+}
+
+// TableModel provides data for Table.
+// Cells are requested only for visible rows.
+type TableModel interface {
+	Rows() int
+	Cell(row, col int) TableCell
+}
+
+// TableSorter can be implemented by TableModel
+// to sort rows when column header is clicked.
+type TableSorter interface {
+	Sort(col int, ascending bool)
+}
+
+// TableColumn describes one column of table.
+type TableColumn struct {
+	Title string
+	Width float64
+	// MinWidth limits column resizing. If zero, 10 is used.
+	MinWidth float64
+	// Align of cell text.
+	Align Align
+	// Sortable columns are sorted by clicking header.
+	Sortable bool
+}
+
+// Distance from column border to start resizing (pixels).
+const tableResizeMargin = 4
+
+// tableRows adapts table model to list model.
+type tableRows struct {
+	t *Table
+}
+
+// Len ...
+func (tr tableRows) Len() int {
+	if tr.t.Data == nil {
+		return 0
+	}
+	return tr.t.Data.Rows()
+}
+
+// Item ...
+func (tr tableRows) Item(i int) ListItem {
+	return ListItem{}
+}
+
+// Table displays data model in rows and columns
+// with header row. It is a ListView which rows are
+// divided into cells, so selection and scrolling
+// work the same.
+type Table struct {
+	*ListView
+	Columns []TableColumn
+	// Data provides cells. Selection isn't remapped when rows
+	// change, call ClearSelection after changing them.
+	Data TableModel
+	// HeaderHeight is height of header row.
+	// If zero, row height is used.
+	HeaderHeight float64
+	// SortColumn is index of sorted column, -1 if none.
+	SortColumn    int
+	SortAscending bool
+
+	OnSort func(col int, ascending bool)
+
+	resizeCol   int
+	resizeStart float64
+	resizeWidth float64
+}
+
+// NewTable creates new table.
+func NewTable(parent Widget, b Base, data TableModel, cols ...TableColumn) *Table {
+	t := &Table{
+		ListView:   NewListView(nil, b, nil),
+		Columns:    cols,
+		Data:       data,
+		SortColumn: -1,
+		resizeCol:  -1,
+	}
+	InitWidget(parent, t)
+	t.Model = tableRows{t}
+	t.AlternateRows = true
+	t.drawRow = t.drawCells
+	t.header = t.headerHeight()
+
+	t.OnMouseDown = func(bt Button) {
+		pos := t.Surface.MousePos()
+		if !t.HeaderRect().Contains(pos) {
+			t.onMouseDown(bt)
+			return
+		}
+		if bt != MouseButtonLeft || t.IsDisabled() {
+			return
+		}
+		if c := t.borderAt(pos); c >= 0 {
+			t.resizeCol = c
+			t.resizeStart = pos.X
+			t.resizeWidth = t.Columns[c].Width
+			return
+		}
+		if c := t.ColumnAt(pos); c >= 0 && t.Columns[c].Sortable {
+			asc := true
+			if c == t.SortColumn {
+				asc = !t.SortAscending
+			}
+			t.Sort(c, asc)
+		}
+	}
+	t.OnMouseUp = func(bt Button) {
+		if bt == MouseButtonLeft {
+			t.resizeCol = -1
+			t.dragThumb = false
+		}
+	}
+	t.OnMouseMove = func() {
+		if t.resizeCol < 0 {
+			t.onMouseMove()
+		}
+	}
+	return t
+}
+
+// ProcessMouse generates mouse events. Besides Panel
+// processing, it tracks resizing of column even if
+// pointer leaves the table.
+func (t *Table) ProcessMouse(wu Widget) {
+	if t.resizeCol >= 0 {
+		if t.Surface.Pressed(MouseButtonLeft) {
+			col := &t.Columns[t.resizeCol]
+			min := col.MinWidth
+			if min == 0 {
+				min = 10
+			}
+			col.Width = math.Max(min, t.resizeWidth+t.Surface.MousePos().X-t.resizeStart)
+		} else {
+			t.resizeCol = -1
+		}
+	}
+	t.ListView.ProcessMouse(wu)
+}
+
+// Sort sorts table by column. Data is sorted
+// if it implements TableSorter. Selection is cleared.
+func (t *Table) Sort(col int, ascending bool) {
+	t.SortColumn = col
+	t.SortAscending = ascending
+	if ts, ok := t.Data.(TableSorter); ok {
+		ts.Sort(col, ascending)
+	}
+	t.ClearSelection()
+	t.Current = -1
+	if t.OnSort != nil {
+		t.OnSort(col, ascending)
+	}
+}
+
+// HeaderRect returns rectangle of header row (screen coords).
+func (t *Table) HeaderRect() Rect {
+	r := t.GlobalRect().Expanded(-t.MyTheme().Pad / 2)
+	return R(r.Min.X, r.Max.Y-t.header, r.Max.X, r.Max.Y)
+}
+
+// ColumnAt returns index of column at given position
+// (screen coords), -1 if there is none.
+func (t *Table) ColumnAt(pos Vec) int {
+	x := t.HeaderRect().Min.X
+	for i, c := range t.Columns {
+		if pos.X >= x && pos.X < x+c.Width {
+			return i
+		}
+		x += c.Width
+	}
+	return -1
+}
+
+// Return index of column which right border is at position.
+func (t *Table) borderAt(pos Vec) int {
+	x := t.HeaderRect().Min.X
+	for i, c := range t.Columns {
+		x += c.Width
+		if math.Abs(pos.X-x) <= tableResizeMargin {
+			return i
+		}
+	}
+	return -1
+}
+
+func (t *Table) headerHeight() float64 {
+	if t.HeaderHeight > 0 {
+		return t.HeaderHeight
+	}
+	return t.rowHeight()
+}
+
+// Paint draws the widget without children.
+func (t *Table) Paint() {
+	t.header = t.headerHeight()
+	t.ListView.Paint()
+
+	theme := t.MyTheme()
+	tcol := theme.ButtonTextColor
+	if tcol == nil {
+		tcol = theme.TextColor
+	}
+	if t.IsDisabled() {
+		tcol = theme.DisabledTextColor
+	}
+	hr := t.HeaderRect()
+	hover := -1
+	if t.PointerInside && !t.IsDisabled() && hr.Contains(t.Surface.MousePos()) {
+		hover = t.ColumnAt(t.Surface.MousePos())
+	}
+	x := hr.Min.X
+	for i, c := range t.Columns {
+		if x >= hr.Max.X {
+			break
+		}
+		cr := R(x, hr.Min.Y, math.Min(x+c.Width, hr.Max.X), hr.Max.Y)
+		x += c.Width
+		td, _ := theme.Drawers[ThemeTableHeader]
+		if i == hover || i == t.resizeCol {
+			if tcur, _ := theme.Drawers[ThemeTableHeaderHL]; tcur != nil {
+				td = tcur
+			}
+		}
+		if td != nil {
+			td.Draw(t.Surface, cr, t.Extras...)
+		}
+		if i == t.SortColumn {
+			key := ThemeTableSortDesc
+			if t.SortAscending {
+				key = ThemeTableSortAsc
+			}
+			if td, _ := theme.Drawers[key]; td != nil {
+				td.Draw(t.Surface, R(cr.Max.X-cr.H(), cr.Min.Y, cr.Max.X, cr.Max.Y), t.Extras...)
+			}
+			cr.Max.X -= cr.H()
+		}
+		t.DrawImageAndTextIn(cr, "", c.Title, tcol, 0, AlignLeft, Vec{})
+	}
+}
+
+func (t *Table) drawCells(i int, r Rect, textColor color.Color) {
+	x := r.Min.X
+	for j, c := range t.Columns {
+		if x >= r.Max.X {
+			break
+		}
+		cr := R(x, r.Min.Y, math.Min(x+c.Width, r.Max.X), r.Max.Y)
+		x += c.Width
+		cell := t.Data.Cell(i, j)
+		if cell.Paint != nil {
+			cell.Paint(t.Surface, cr)
+			continue
+		}
+		al := c.Align
+		if al == AlignDefault {
+			al = AlignLeft
+		}
+		t.DrawImageAndTextIn(cr, cell.Image, cell.Text, textColor, AlignLeft, al, Vec{})
+	}
+}
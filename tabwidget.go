@@ -0,0 +1,328 @@
+package grue
+
+// Tab is one tab of tab widget.
+type Tab struct {
+	Title    string
+	Image    string
+	Disabled bool
+	// Closable tabs have close button.
+	Closable bool
+	// Page is panel shown when tab is active.
+	// Add page content as children of it.
+	Page *Panel
+}
+
+// TabWidget shows a bar of tabs and the page of active tab.
+// Pages of inactive tabs are hidden.
+type TabWidget struct {
+	*Panel
+	Tabs []*Tab
+	// Current is index of active tab, -1 if none.
+	Current int
+	// BarHeight is height of tab bar.
+	// If zero, it's derived from font height.
+	BarHeight float64
+
+	OnTabChanged func(index int)
+	// OnTabClose is called when close button of tab is pressed.
+	// Tab is removed if it returns true or if OnTabClose is nil.
+	OnTabClose func(index int) bool
+
+	// Index of the first visible tab when bar is scrolled.
+	first int
+}
+
+// NewTabWidget creates new tab widget.
+func NewTabWidget(parent Widget, b Base) *TabWidget {
+	tw := &TabWidget{
+		Panel:   NewPanel(nil, b),
+		Current: -1,
+	}
+	InitWidget(parent, tw)
+	tw.FocusPolicy = FocusStrong
+
+	tw.OnMouseDown = tw.onMouseDown
+	tw.OnMouseWheel = func() {
+		if tw.BarRect().Contains(tw.Surface.MousePos()) {
+			tw.scrollTabs(-int(tw.Surface.MouseScroll().Y))
+		}
+	}
+	tw.OnKeys = tw.onKeys
+	return tw
+}
+
+// AddTab adds new tab with empty page and returns it.
+// First added tab becomes active.
+func (tw *TabWidget) AddTab(title, image string) *Tab {
+	pg := NewPanel(tw, Base{
+		Rect:   R0(tw.Rect.W(), tw.Rect.H()-tw.barHeight()),
+		Hidden: true,
+	})
+	t := &Tab{
+		Title: title,
+		Image: image,
+		Page:  pg,
+	}
+	tw.Tabs = append(tw.Tabs, t)
+	if tw.Current < 0 {
+		tw.SetCurrent(len(tw.Tabs) - 1)
+	}
+	return t
+}
+
+// RemoveTab removes tab and closes its page.
+func (tw *TabWidget) RemoveTab(i int) {
+	if i < 0 || i >= len(tw.Tabs) {
+		return
+	}
+	cur := tw.Current
+	if i == cur {
+		tw.showPage(i, false)
+	}
+	tw.Tabs[i].Page.Close()
+	copy(tw.Tabs[i:], tw.Tabs[i+1:])
+	tw.Tabs[len(tw.Tabs)-1] = nil
+	tw.Tabs = tw.Tabs[:len(tw.Tabs)-1]
+	tw.scrollTabs(0)
+
+	switch {
+	case i < cur:
+		tw.Current--
+	case i == cur:
+		tw.Current = -1
+		if cur >= len(tw.Tabs) {
+			cur = len(tw.Tabs) - 1
+		}
+		tw.SetCurrent(cur)
+	}
+}
+
+// SetCurrent activates tab by index.
+func (tw *TabWidget) SetCurrent(i int) {
+	if i < 0 || i >= len(tw.Tabs) || i == tw.Current {
+		return
+	}
+	tw.showPage(tw.Current, false)
+	tw.Current = i
+	tw.showPage(i, true)
+	tw.ensureVisible(i)
+	if tw.OnTabChanged != nil {
+		tw.OnTabChanged(i)
+	}
+}
+
+// Show or hide tab page.
+func (tw *TabWidget) showPage(i int, show bool) {
+	if i < 0 || i >= len(tw.Tabs) {
+		return
+	}
+	tw.Tabs[i].Page.SetHidden(!show)
+}
+
+// BarRect returns rectangle of tab bar (screen coords).
+func (tw *TabWidget) BarRect() Rect {
+	r := tw.GlobalRect()
+	return R(r.Min.X, r.Max.Y-tw.barHeight(), r.Max.X, r.Max.Y)
+}
+
+func (tw *TabWidget) barHeight() float64 {
+	if tw.BarHeight > 0 {
+		return tw.BarHeight
+	}
+	theme := tw.MyTheme()
+	return tw.Surface.GetTextRect("Wg", theme.TitleFont).H() + theme.Pad*2
+}
+
+func (tw *TabWidget) tabWidth(t *Tab) float64 {
+	theme := tw.MyTheme()
+	w := tw.Surface.GetTextRect(t.Title, theme.TitleFont).W() + theme.Pad*2
+	if t.Image != "" {
+		w += tw.Surface.GetImageRect(t.Image).W() + theme.Pad
+	}
+	if t.Closable {
+		w += tw.barHeight()
+	}
+	return w
+}
+
+// Returns rectangles of visible tabs, starting from
+// the first one, and whether tabs don't fit into bar.
+func (tw *TabWidget) layout() (rects []Rect, overflow bool) {
+	bar := tw.BarRect()
+	total := 0.0
+	for _, t := range tw.Tabs {
+		total += tw.tabWidth(t)
+	}
+	avail := bar.Max.X
+	if total > bar.W() {
+		overflow = true
+		avail -= bar.H() * 2
+	}
+	x := bar.Min.X
+	for i := tw.first; i < len(tw.Tabs); i++ {
+		w := tw.tabWidth(tw.Tabs[i])
+		if x+w > avail && i > tw.first {
+			break
+		}
+		rects = append(rects, R(x, bar.Min.Y, x+w, bar.Max.Y))
+		x += w
+	}
+	return
+}
+
+// Returns rectangles of scroll arrows.
+func (tw *TabWidget) arrowRects() (left, right Rect) {
+	bar := tw.BarRect()
+	h := bar.H()
+	left = R(bar.Max.X-h*2, bar.Min.Y, bar.Max.X-h, bar.Max.Y)
+	right = R(bar.Max.X-h, bar.Min.Y, bar.Max.X, bar.Max.Y)
+	return
+}
+
+func closeRect(r Rect) Rect {
+	return R(r.Max.X-r.H(), r.Min.Y, r.Max.X, r.Max.Y)
+}
+
+func (tw *TabWidget) scrollTabs(delta int) {
+	tw.first += delta
+	if tw.first >= len(tw.Tabs) {
+		tw.first = len(tw.Tabs) - 1
+	}
+	if tw.first < 0 {
+		tw.first = 0
+	}
+}
+
+// Scroll tab bar so tab is visible.
+func (tw *TabWidget) ensureVisible(i int) {
+	if i < tw.first {
+		tw.first = i
+		return
+	}
+	for tw.first < i {
+		rects, _ := tw.layout()
+		if i < tw.first+len(rects) {
+			return
+		}
+		tw.first++
+	}
+}
+
+func (tw *TabWidget) onMouseDown(bt Button) {
+	if bt != MouseButtonLeft || tw.IsDisabled() {
+		return
+	}
+	pos := tw.Surface.MousePos()
+	rects, overflow := tw.layout()
+	if overflow {
+		left, right := tw.arrowRects()
+		if left.Contains(pos) {
+			tw.scrollTabs(-1)
+			return
+		}
+		if right.Contains(pos) {
+			if tw.first+len(rects) < len(tw.Tabs) {
+				tw.scrollTabs(1)
+			}
+			return
+		}
+	}
+	for k, r := range rects {
+		if !r.Contains(pos) {
+			continue
+		}
+		i := tw.first + k
+		t := tw.Tabs[i]
+		if t.Closable && closeRect(r).Contains(pos) {
+			if tw.OnTabClose == nil || tw.OnTabClose(i) {
+				tw.RemoveTab(i)
+			}
+			return
+		}
+		if !t.Disabled {
+			tw.SetCurrent(i)
+		}
+		return
+	}
+}
+
+func (tw *TabWidget) onKeys() bool {
+	f := tw.Surface.Focus()
+	if tw.IsDisabled() || len(tw.Tabs) == 0 || f == nil || !tw.IsAncestorOf(f) {
+		return false
+	}
+	s := tw.Surface
+	ctrl, shift := modifiers(s)
+	if !ctrl || !(s.JustPressed(KeyTab) || s.Repeated(KeyTab)) {
+		return false
+	}
+	delta := 1
+	if shift {
+		delta = -1
+	}
+	n := len(tw.Tabs)
+	i := tw.Current
+	for k := 0; k < n; k++ {
+		i = (i + delta + n) % n
+		if !tw.Tabs[i].Disabled {
+			tw.SetCurrent(i)
+			break
+		}
+	}
+	return true
+}
+
+// Paint draws the widget without children.
+func (tw *TabWidget) Paint() {
+	theme := tw.MyTheme()
+	bar := tw.BarRect()
+	if td, _ := theme.Drawers[ThemeTabBar]; td != nil {
+		td.Draw(tw.Surface, bar, tw.Extras...)
+	}
+	textColor := theme.ButtonTextColor
+	if textColor == nil {
+		textColor = theme.TextColor
+	}
+	pos := tw.Surface.MousePos()
+	rects, overflow := tw.layout()
+	for k, r := range rects {
+		i := tw.first + k
+		t := tw.Tabs[i]
+		tdef, _ := theme.Drawers[ThemeTab]
+		var tcur ThemeDrawer
+		tcol := textColor
+		switch {
+		case tw.IsDisabled() || t.Disabled:
+			tcur, _ = theme.Drawers[ThemeTabDisabled]
+			tcol = theme.DisabledTextColor
+		case i == tw.Current:
+			tcur, _ = theme.Drawers[ThemeTabActive]
+		case tw.PointerInside && r.Contains(pos):
+			tcur, _ = theme.Drawers[ThemeTabHL]
+		}
+		if tcur != nil {
+			tdef = tcur
+		}
+		if tdef != nil {
+			tdef.Draw(tw.Surface, r, tw.Extras...)
+		}
+		tr := r
+		if t.Closable {
+			cr := closeRect(r)
+			if td, _ := theme.Drawers[ThemeTabClose]; td != nil {
+				td.Draw(tw.Surface, cr, tw.Extras...)
+			}
+			tr.Max.X = cr.Min.X + theme.Pad
+		}
+		tw.DrawImageAndTextIn(tr, t.Image, t.Title, tcol, AlignLeft, AlignDefault, Vec{})
+	}
+	if overflow {
+		left, right := tw.arrowRects()
+		if td, _ := theme.Drawers[ThemeTabScrollLeft]; td != nil {
+			td.Draw(tw.Surface, left, tw.Extras...)
+		}
+		if td, _ := theme.Drawers[ThemeTabScrollRight]; td != nil {
+			td.Draw(tw.Surface, right, tw.Extras...)
+		}
+	}
+}
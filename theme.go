@@ -18,6 +18,7 @@ type Theme struct {
 	PanelTextColor   color.Color
 	EditTextColor    color.Color
 	PlaceholderColor color.Color
+	ListTextColor    color.Color
 
 	DisabledTextColor color.Color
 	TooltipColor      color.Color
@@ -28,6 +29,9 @@ type Theme struct {
 	// Vector to dispace test for pressed buttons
 	PressDisplace Vec
 
+	// Width of scroll bars. If zero, 10 is used.
+	ScrollBarWidth float64
+
 	// Drawers
 	Drawers      map[ThemeDrawerKey]ThemeDrawer
 	CursorDrawer CursorDrawer
@@ -54,6 +58,96 @@ const (
 	ThemeLineEditHL       ThemeDrawerKey = "le-h"
 	ThemeLineEditActive   ThemeDrawerKey = "le-a"
 	ThemeTooltip          ThemeDrawerKey = "tip"
+
+	ThemeComboBox         ThemeDrawerKey = "cb"
+	ThemeComboBoxDisabled ThemeDrawerKey = "cb-d"
+	ThemeComboBoxHL       ThemeDrawerKey = "cb-h"
+	ThemeComboBoxActive   ThemeDrawerKey = "cb-a"
+	// Arrow indicator, drawn in the right square of combo box.
+	ThemeComboBoxArrow ThemeDrawerKey = "cb-ar"
+
+	ThemeListView            ThemeDrawerKey = "lv"
+	ThemeListViewDisabled    ThemeDrawerKey = "lv-d"
+	ThemeListViewRow         ThemeDrawerKey = "lv-r"
+	ThemeListViewRowAlt      ThemeDrawerKey = "lv-ra"
+	ThemeListViewRowHL       ThemeDrawerKey = "lv-rh"
+	ThemeListViewRowSelected ThemeDrawerKey = "lv-rs"
+	// Keyboard cursor, drawn over row when view has focus.
+	ThemeListViewRowCurrent ThemeDrawerKey = "lv-rc"
+
+	// Expand/collapse indicators and indentation guide lines.
+	ThemeTreeViewExpanded  ThemeDrawerKey = "tv-e"
+	ThemeTreeViewCollapsed ThemeDrawerKey = "tv-c"
+	ThemeTreeViewGuide     ThemeDrawerKey = "tv-g"
+
+	ThemeTableHeader   ThemeDrawerKey = "tb-h"
+	ThemeTableHeaderHL ThemeDrawerKey = "tb-hh"
+	// Sort direction indicators, drawn in the right
+	// square of header of sorted column.
+	ThemeTableSortAsc  ThemeDrawerKey = "tb-sa"
+	ThemeTableSortDesc ThemeDrawerKey = "tb-sd"
+
+	ThemeTabBar         ThemeDrawerKey = "tab-bar"
+	ThemeTab            ThemeDrawerKey = "tab"
+	ThemeTabDisabled    ThemeDrawerKey = "tab-d"
+	ThemeTabHL          ThemeDrawerKey = "tab-h"
+	ThemeTabActive      ThemeDrawerKey = "tab-a"
+	ThemeTabClose       ThemeDrawerKey = "tab-x"
+	ThemeTabScrollLeft  ThemeDrawerKey = "tab-sl"
+	ThemeTabScrollRight ThemeDrawerKey = "tab-sr"
+
+	ThemeProgressGroove ThemeDrawerKey = "pb-g"
+	ThemeProgressFill   ThemeDrawerKey = "pb-f"
+
+	ThemeDialog      ThemeDrawerKey = "dlg"
+	ThemeDialogTitle ThemeDrawerKey = "dlg-t"
+	// Drawn over the whole surface behind modal dialog.
+	ThemeDialogDim ThemeDrawerKey = "dlg-dim"
+
+	ThemeWindow            ThemeDrawerKey = "win"
+	ThemeWindowTitle       ThemeDrawerKey = "win-t"
+	ThemeWindowTitleActive ThemeDrawerKey = "win-ta"
+	ThemeWindowClose       ThemeDrawerKey = "win-x"
+	ThemeWindowMinimize    ThemeDrawerKey = "win-m"
+
+	ThemeMenuBar           ThemeDrawerKey = "mb"
+	ThemeMenuBarItemHL     ThemeDrawerKey = "mb-h"
+	ThemeMenuBarItemActive ThemeDrawerKey = "mb-a"
+	// Drawn in thin rect in the middle of separator option.
+	ThemeMenuSeparator ThemeDrawerKey = "mn-s"
+	// Drawn in square at the left side of checked option.
+	ThemeMenuCheck ThemeDrawerKey = "mn-c"
+	ThemeMenuRadio ThemeDrawerKey = "mn-r"
+	// Drawn in square at the right side of option with submenu.
+	ThemeMenuSubmenu ThemeDrawerKey = "mn-sub"
+
+	ThemeToolbar ThemeDrawerKey = "tool"
+	// Drawn in thin rect in the middle of separator.
+	ThemeToolbarSeparator ThemeDrawerKey = "tool-s"
+	// Drawn over overflow menu button.
+	ThemeToolbarOverflow ThemeDrawerKey = "tool-of"
+
+	ThemeStatusBar ThemeDrawerKey = "st"
+	// Drawn in each section of status bar.
+	ThemeStatusSection ThemeDrawerKey = "st-s"
+
+	ThemeToastInfo    ThemeDrawerKey = "toast-i"
+	ThemeToastWarning ThemeDrawerKey = "toast-w"
+	ThemeToastError   ThemeDrawerKey = "toast-e"
+
+	// Drawn around color square, strips and swatches.
+	ThemeColorPickerFrame ThemeDrawerKey = "cp-f"
+	// Drawn at current position in color square and strips.
+	ThemeColorPickerMarker ThemeDrawerKey = "cp-m"
+
+	// Drawn over widget having keyboard focus.
+	ThemeFocusRing ThemeDrawerKey = "focus"
+
+	ThemeSplitterHandle   ThemeDrawerKey = "spl"
+	ThemeSplitterHandleHL ThemeDrawerKey = "spl-h"
+
+	ThemeScrollBar      ThemeDrawerKey = "sb"
+	ThemeScrollBarThumb ThemeDrawerKey = "sb-t"
 )
 
 // MultiDrawer is a drawer combining several other drawers.
@@ -72,3 +166,10 @@ func (md MultiDrawer) Draw(s Surface, rect Rect, extras ...interface{}) {
 type CursorDrawer interface {
 	Draw(s Surface, pos Vec, height float64)
 }
+
+func (t *Theme) scrollBarWidth() float64 {
+	if t.ScrollBarWidth == 0 {
+		return 10
+	}
+	return t.ScrollBarWidth
+}
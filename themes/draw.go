@@ -2,6 +2,7 @@ package themes
 
 import (
 	"image/color"
+	"math"
 
 	"github.com/gremour/grue"
 	"github.com/gremour/grue/particles"
@@ -250,3 +251,193 @@ func (pd ParticleDrawer) Draw(s grue.Surface, rect grue.Rect, extras ...interfac
 	pg.Process(s.TotalTime())
 	pg.Draw(s)
 }
+
+// ArrowDrawer draws triangle arrow centered in rect.
+type ArrowDrawer struct {
+	Color color.Color
+	// Direction arrow points to: AlignBottom (default),
+	// AlignTop, AlignLeft or AlignRight.
+	Direction grue.Align
+	// Size is the length of the arrow base.
+	// If zero, third of the smaller rect side is used.
+	Size float64
+}
+
+// Draw ...
+func (ad ArrowDrawer) Draw(s grue.Surface, rect grue.Rect, extras ...interface{}) {
+	if ad.Color == nil {
+		return
+	}
+	size := ad.Size
+	if size == 0 {
+		size = math.Floor(math.Min(rect.W(), rect.H()) / 3)
+	}
+	h := math.Floor(size / 2)
+	c := rect.Center()
+	// Triangle is drawn as a stack of 1 pixel strips,
+	// starting from the apex.
+	for k := 0.0; k < h; k++ {
+		w := size / 2 * (k + 1) / h
+		switch ad.Direction {
+		case grue.AlignTop:
+			y := c.Y + h/2 - k - 1
+			s.DrawFillRect(grue.R(c.X-w, y, c.X+w, y+1), ad.Color)
+		case grue.AlignLeft:
+			x := c.X - h/2 + k
+			s.DrawFillRect(grue.R(x, c.Y-w, x+1, c.Y+w), ad.Color)
+		case grue.AlignRight:
+			x := c.X + h/2 - k - 1
+			s.DrawFillRect(grue.R(x, c.Y-w, x+1, c.Y+w), ad.Color)
+		default:
+			y := c.Y - h/2 + k
+			s.DrawFillRect(grue.R(c.X-w, y, c.X+w, y+1), ad.Color)
+		}
+	}
+}
+
+// CrossDrawer draws diagonal cross centered in rect.
+type CrossDrawer struct {
+	Color color.Color
+	// Size is the width and height of the cross.
+	// If zero, third of the smaller rect side is used.
+	Size float64
+	// Thickness of lines. If zero, 2 is used.
+	Thick float64
+}
+
+// Draw ...
+func (cd CrossDrawer) Draw(s grue.Surface, rect grue.Rect, extras ...interface{}) {
+	if cd.Color == nil {
+		return
+	}
+	size := cd.Size
+	if size == 0 {
+		size = math.Floor(math.Min(rect.W(), rect.H()) / 3)
+	}
+	thick := cd.Thick
+	if thick == 0 {
+		thick = 2
+	}
+	c := rect.Center()
+	x0 := c.X - size/2
+	y0 := c.Y - size/2
+	for k := 0.0; k <= size-thick; k++ {
+		s.DrawFillRect(grue.R(x0+k, y0+k, x0+k+thick, y0+k+thick), cd.Color)
+		s.DrawFillRect(grue.R(x0+k, y0+size-k-thick, x0+k+thick, y0+size-k), cd.Color)
+	}
+}
+
+// CheckDrawer draws check mark or radio button dot
+// centered in rect.
+type CheckDrawer struct {
+	Color color.Color
+	// Radio draws round dot instead of check mark.
+	Radio bool
+	// Size is the width and height of the mark.
+	// If zero, third of the smaller rect side is used.
+	Size float64
+	// Thickness of check mark lines. If zero, 2 is used.
+	Thick float64
+}
+
+// Draw ...
+func (cd CheckDrawer) Draw(s grue.Surface, rect grue.Rect, extras ...interface{}) {
+	if cd.Color == nil {
+		return
+	}
+	size := cd.Size
+	if size == 0 {
+		size = math.Floor(math.Min(rect.W(), rect.H()) / 3)
+	}
+	c := rect.Center()
+	x0 := math.Floor(c.X - size/2)
+	y0 := math.Floor(c.Y - size/2)
+	if cd.Radio {
+		// Dot is drawn as a stack of 1 pixel strips.
+		r := size / 2
+		for k := 0.0; k < size; k++ {
+			dy := k + 0.5 - r
+			w := math.Round(math.Sqrt(r*r - dy*dy))
+			s.DrawFillRect(grue.R(c.X-w, y0+k, c.X+w, y0+k+1), cd.Color)
+		}
+		return
+	}
+	thick := cd.Thick
+	if thick == 0 {
+		thick = 2
+	}
+	// Short stroke goes down to the third of width,
+	// long one goes up from there.
+	third := math.Floor(size / 3)
+	for k := 0.0; k <= third; k++ {
+		s.DrawFillRect(grue.R(x0+k, y0+third-k, x0+k+thick, y0+third-k+thick), cd.Color)
+	}
+	for k := 0.0; k <= size-third-thick; k++ {
+		s.DrawFillRect(grue.R(x0+third+k, y0+k, x0+third+k+thick, y0+k+thick), cd.Color)
+	}
+}
+
+// RoundedRect draws rectangle with rounded corners
+// and optional border.
+type RoundedRect struct {
+	BackColor   color.Color
+	BorderColor color.Color
+	BorderSize  float64
+	// Radii of corners, see grue.Radii for uniform ones.
+	Radii grue.CornerRadii
+}
+
+// Draw ...
+func (rr RoundedRect) Draw(s grue.Surface, rect grue.Rect, extras ...interface{}) {
+	if rr.BackColor != nil {
+		s.DrawFillRoundedRect(rect, rr.Radii, rr.BackColor)
+	}
+	if rr.BorderColor != nil && rr.BorderSize > 0 {
+		s.DrawRoundedRect(rect, rr.Radii, rr.BorderColor, rr.BorderSize)
+	}
+}
+
+// GradientRect fills rectangle with linear gradient,
+// or inscribed ellipse with radial gradient.
+type GradientRect struct {
+	// From is color at the left (Horizontal), top (Vertical)
+	// or center (Radial).
+	From color.Color
+	To   color.Color
+	// Orientation of linear gradient.
+	Orientation grue.Orientation
+	Radial      bool
+}
+
+// Draw ...
+func (gr GradientRect) Draw(s grue.Surface, rect grue.Rect, extras ...interface{}) {
+	if gr.From == nil || gr.To == nil {
+		return
+	}
+	if gr.Radial {
+		s.DrawRadialGradient(rect, gr.From, gr.To)
+		return
+	}
+	s.DrawGradientRect(rect, gr.From, gr.To, gr.Orientation)
+}
+
+// Shadow draws soft drop shadow of rect. Put it first
+// into grue.MultiDrawer, so panel is drawn over it.
+type Shadow struct {
+	Color color.Color
+	// Blur is the distance shadow fades out over.
+	Blur float64
+	// Offset of shadow relative to rect.
+	Offset grue.Vec
+	// Spread expands (or shrinks if negative) shadow rect.
+	Spread float64
+	Radii  grue.CornerRadii
+}
+
+// Draw ...
+func (sh Shadow) Draw(s grue.Surface, rect grue.Rect, extras ...interface{}) {
+	if sh.Color == nil {
+		return
+	}
+	s.DrawShadow(rect.Moved(sh.Offset).Expanded(sh.Spread), sh.Radii, sh.Color, sh.Blur)
+}
@@ -55,6 +55,245 @@ func NewLight(s grue.Surface, fontFile string, fontSize float64, sheetFile strin
 				Image: "light-le",
 				Left:  4, Right: 4, Top: 4, Bottom: 4,
 			},
+			grue.ThemeComboBox: TexturedPanel{
+				Image: "light-bt",
+				Left:  4, Right: 4, Top: 4, Bottom: 4,
+			},
+			grue.ThemeComboBoxDisabled: TexturedPanel{
+				Image: "light-bt",
+				Color: grue.RGB(0.8, 0.8, 0.8),
+				Left:  4, Right: 4, Top: 4, Bottom: 4,
+			},
+			grue.ThemeComboBoxHL: TexturedPanel{
+				Image: "light-bt",
+				Color: grue.RGB(0.8, 1, 1),
+				Left:  4, Right: 4, Top: 4, Bottom: 4,
+			},
+			grue.ThemeComboBoxActive: TexturedPanel{
+				Image: "light-bt-act",
+				Color: grue.RGB(0.8, 1, 1),
+				Left:  4, Right: 4, Top: 4, Bottom: 4,
+			},
+			grue.ThemeComboBoxArrow: ArrowDrawer{
+				Color: grue.RGB(0.2, 0.2, 0.2),
+			},
+			grue.ThemeListView: PlainRect{
+				BackColor:   grue.RGB(1, 1, 1),
+				BorderColor: grue.RGB(0.4, 0.4, 0.4),
+				BorderSize:  1,
+			},
+			grue.ThemeListViewDisabled: PlainRect{
+				BackColor:   grue.RGB(0.8, 0.8, 0.8),
+				BorderColor: grue.RGB(0.4, 0.4, 0.4),
+				BorderSize:  1,
+			},
+			grue.ThemeListViewRowHL: PlainRect{
+				BackColor: grue.RGB(0.85, 0.95, 1),
+			},
+			grue.ThemeListViewRowSelected: PlainRect{
+				BackColor: grue.RGB(0.6, 0.8, 1),
+			},
+			grue.ThemeListViewRowCurrent: PlainRect{
+				BorderColor: grue.RGB(0.2, 0.4, 0.8),
+				BorderSize:  1,
+			},
+			grue.ThemeTreeViewExpanded: ArrowDrawer{
+				Color: grue.RGB(0.2, 0.2, 0.2),
+			},
+			grue.ThemeTreeViewCollapsed: ArrowDrawer{
+				Color:     grue.RGB(0.2, 0.2, 0.2),
+				Direction: grue.AlignRight,
+			},
+			grue.ThemeTreeViewGuide: PlainRect{
+				BackColor: grue.RGB(0.7, 0.7, 0.7),
+			},
+			grue.ThemeListViewRowAlt: PlainRect{
+				BackColor: grue.RGB(0.95, 0.95, 0.95),
+			},
+			grue.ThemeTableHeader: PlainRect{
+				BackColor:   grue.RGB(0.8, 0.8, 0.8),
+				BorderColor: grue.RGB(0.5, 0.5, 0.5),
+				BorderSize:  1,
+			},
+			grue.ThemeTableHeaderHL: PlainRect{
+				BackColor:   grue.RGB(0.85, 0.95, 1),
+				BorderColor: grue.RGB(0.5, 0.5, 0.5),
+				BorderSize:  1,
+			},
+			grue.ThemeTableSortAsc: ArrowDrawer{
+				Color:     grue.RGB(0.2, 0.2, 0.2),
+				Direction: grue.AlignTop,
+			},
+			grue.ThemeTableSortDesc: ArrowDrawer{
+				Color: grue.RGB(0.2, 0.2, 0.2),
+			},
+			grue.ThemeTabBar: PlainRect{
+				BackColor: grue.RGB(0.6, 0.6, 0.6),
+			},
+			grue.ThemeTab: TexturedPanel{
+				Image: "light-bt",
+				Left:  4, Right: 4, Top: 4, Bottom: 4,
+			},
+			grue.ThemeTabDisabled: TexturedPanel{
+				Image: "light-bt",
+				Color: grue.RGB(0.8, 0.8, 0.8),
+				Left:  4, Right: 4, Top: 4, Bottom: 4,
+			},
+			grue.ThemeTabHL: TexturedPanel{
+				Image: "light-bt",
+				Color: grue.RGB(0.8, 1, 1),
+				Left:  4, Right: 4, Top: 4, Bottom: 4,
+			},
+			grue.ThemeTabActive: TexturedPanel{
+				Image: "light-bt-act",
+				Left:  4, Right: 4, Top: 4, Bottom: 4,
+			},
+			grue.ThemeTabClose: CrossDrawer{
+				Color: grue.RGB(0.2, 0.2, 0.2),
+			},
+			grue.ThemeTabScrollLeft: ArrowDrawer{
+				Color:     grue.RGB(0.2, 0.2, 0.2),
+				Direction: grue.AlignLeft,
+			},
+			grue.ThemeTabScrollRight: ArrowDrawer{
+				Color:     grue.RGB(0.2, 0.2, 0.2),
+				Direction: grue.AlignRight,
+			},
+			grue.ThemeProgressGroove: PlainRect{
+				BackColor:   grue.RGB(1, 1, 1),
+				BorderColor: grue.RGB(0.4, 0.4, 0.4),
+				BorderSize:  1,
+			},
+			grue.ThemeProgressFill: PlainRect{
+				BackColor: grue.RGB(0.4, 0.7, 1),
+			},
+			grue.ThemeDialog: PlainRect{
+				BackColor:   grue.RGB(0.85, 0.85, 0.85),
+				BorderColor: grue.RGB(0.3, 0.3, 0.3),
+				BorderSize:  1,
+			},
+			grue.ThemeDialogTitle: PlainRect{
+				BackColor:   grue.RGB(0.6, 0.75, 0.9),
+				BorderColor: grue.RGB(0.3, 0.3, 0.3),
+				BorderSize:  1,
+			},
+			grue.ThemeDialogDim: PlainRect{
+				BackColor: grue.RGBA(0, 0, 0, 0.4),
+			},
+			grue.ThemeWindow: PlainRect{
+				BackColor:   grue.RGB(0.8, 0.8, 0.8),
+				BorderColor: grue.RGB(0.3, 0.3, 0.3),
+				BorderSize:  1,
+			},
+			grue.ThemeWindowTitle: PlainRect{
+				BackColor:   grue.RGB(0.7, 0.7, 0.7),
+				BorderColor: grue.RGB(0.3, 0.3, 0.3),
+				BorderSize:  1,
+			},
+			grue.ThemeWindowTitleActive: PlainRect{
+				BackColor:   grue.RGB(0.6, 0.75, 0.9),
+				BorderColor: grue.RGB(0.3, 0.3, 0.3),
+				BorderSize:  1,
+			},
+			grue.ThemeWindowClose: CrossDrawer{
+				Color: grue.RGB(0.2, 0.2, 0.2),
+			},
+			grue.ThemeWindowMinimize: ArrowDrawer{
+				Color:     grue.RGB(0.2, 0.2, 0.2),
+				Direction: grue.AlignTop,
+			},
+			grue.ThemeMenuBar: PlainRect{
+				BackColor:   grue.RGB(0.85, 0.85, 0.85),
+				BorderColor: grue.RGB(0.4, 0.4, 0.4),
+				BorderSize:  1,
+			},
+			grue.ThemeMenuBarItemHL: PlainRect{
+				BackColor: grue.RGB(0.75, 0.85, 0.95),
+			},
+			grue.ThemeMenuBarItemActive: PlainRect{
+				BackColor: grue.RGB(0.6, 0.75, 0.9),
+			},
+			grue.ThemeMenuSeparator: PlainRect{
+				BackColor: grue.RGB(0.4, 0.4, 0.4),
+			},
+			grue.ThemeMenuCheck: CheckDrawer{
+				Color: grue.RGB(0.2, 0.2, 0.2),
+			},
+			grue.ThemeMenuRadio: CheckDrawer{
+				Color: grue.RGB(0.2, 0.2, 0.2),
+				Radio: true,
+			},
+			grue.ThemeMenuSubmenu: ArrowDrawer{
+				Color:     grue.RGB(0.2, 0.2, 0.2),
+				Direction: grue.AlignRight,
+			},
+			grue.ThemeToolbar: PlainRect{
+				BackColor:   grue.RGB(0.85, 0.85, 0.85),
+				BorderColor: grue.RGB(0.4, 0.4, 0.4),
+				BorderSize:  1,
+			},
+			grue.ThemeToolbarSeparator: PlainRect{
+				BackColor: grue.RGB(0.5, 0.5, 0.5),
+			},
+			grue.ThemeToolbarOverflow: ArrowDrawer{
+				Color: grue.RGB(0.2, 0.2, 0.2),
+			},
+			grue.ThemeStatusBar: PlainRect{
+				BackColor: grue.RGB(0.85, 0.85, 0.85),
+			},
+			grue.ThemeStatusSection: PlainRect{
+				BorderColor: grue.RGB(0.6, 0.6, 0.6),
+				BorderSize:  1,
+			},
+			grue.ThemeToastInfo: PlainRect{
+				BackColor:   grue.RGB(0.9, 0.95, 1),
+				BorderColor: grue.RGB(0.3, 0.5, 0.8),
+				BorderSize:  2,
+			},
+			grue.ThemeToastWarning: PlainRect{
+				BackColor:   grue.RGB(1, 0.95, 0.8),
+				BorderColor: grue.RGB(0.85, 0.6, 0.1),
+				BorderSize:  2,
+			},
+			grue.ThemeToastError: PlainRect{
+				BackColor:   grue.RGB(1, 0.88, 0.88),
+				BorderColor: grue.RGB(0.8, 0.2, 0.2),
+				BorderSize:  2,
+			},
+			grue.ThemeColorPickerFrame: PlainRect{
+				BorderColor: grue.RGB(0.2, 0.2, 0.2),
+				BorderSize:  1,
+			},
+			grue.ThemeColorPickerMarker: grue.MultiDrawer{Drawers: []grue.ThemeDrawer{
+				PlainRect{
+					BorderColor: grue.RGB(0, 0, 0),
+					BorderSize:  1,
+				},
+				PlainRect{
+					BorderColor: grue.RGB(1, 1, 1),
+					BorderSize:  1,
+					BorderInset: 1,
+				},
+			}},
+			grue.ThemeFocusRing: PlainRect{
+				BorderColor: grue.RGB(0.3, 0.5, 0.8),
+				BorderSize:  2,
+				BorderInset: -2,
+			},
+			grue.ThemeSplitterHandle: PlainRect{
+				BackColor: grue.RGB(0.6, 0.6, 0.6),
+			},
+			grue.ThemeSplitterHandleHL: PlainRect{
+				BackColor: grue.RGB(0.6, 0.75, 0.9),
+			},
+			grue.ThemeScrollBar: PlainRect{
+				BackColor: grue.RGB(0.9, 0.9, 0.9),
+			},
+			grue.ThemeScrollBarThumb: PlainRect{
+				BackColor:   grue.RGB(0.6, 0.6, 0.6),
+				BorderColor: grue.RGB(0.4, 0.4, 0.4),
+				BorderSize:  1,
+			},
 			grue.ThemeTooltip: PlainRect{
 				BackColor:   grue.RGB(1, 0.95, 0.8),
 				BorderColor: grue.RGB(0, 0, 0),
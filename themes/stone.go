@@ -1,6 +1,10 @@
 package themes
 
-import "github.com/gremour/grue"
+import (
+	"image/color"
+
+	"github.com/gremour/grue"
+)
 
 // NewStone creates new stone theme.
 func NewStone(s grue.Surface, fontFile string, fontSize float64, sheetFile string) (grue.Theme, error) {
@@ -45,6 +49,20 @@ func NewStone(s grue.Surface, fontFile string, fontSize float64, sheetFile strin
 			Color: grue.RGB(0.7, 0.7, 0.7),
 		},
 	}}
+	toast := func(c color.Color) grue.ThemeDrawer {
+		return grue.MultiDrawer{Drawers: []grue.ThemeDrawer{
+			TexturedPanel{
+				Image:          "stone-bt",
+				TileHorizontal: true, TileVertical: true,
+				Color: c,
+			},
+			TexturedPanel{
+				Image: "stone-orn2",
+				Left:  4, Right: 4, Top: 4, Bottom: 4,
+				Color: c,
+			},
+		}}
+	}
 	btmdhl := btmd
 	btmdhl.Drawers = append(btmdhl.Drawers, ParticleDrawer{})
 	lemd := grue.MultiDrawer{Drawers: []grue.ThemeDrawer{
@@ -67,18 +85,157 @@ func NewStone(s grue.Surface, fontFile string, fontSize float64, sheetFile strin
 		TextColor:         grue.RGB(0.9, 0.7, 0.55),
 		PanelTextColor:    grue.RGB(0, 0, 0),
 		EditTextColor:     grue.RGB(1, 1, 1),
+		ListTextColor:     grue.RGB(1, 1, 1),
 		DisabledTextColor: grue.RGB(0.8, 0.5, 0.5),
 		PlaceholderColor:  grue.RGB(0.7, 0.7, 0.7),
 		TooltipColor:      grue.RGB(0, 0, 0),
 		Pad:               8,
 		//		PressDisplace:     grue.V(1, -1),
 		Drawers: map[grue.ThemeDrawerKey]grue.ThemeDrawer{
-			grue.ThemePanel:        pnmd,
-			grue.ThemeButton:       btmd,
-			grue.ThemeButtonActive: btmda,
-			grue.ThemeButtonHL:     btmdhl,
-			grue.ThemeLineEdit:     lemd,
-			grue.ThemeLineEditHL:   lemdhl,
+			grue.ThemePanel:          pnmd,
+			grue.ThemeButton:         btmd,
+			grue.ThemeButtonActive:   btmda,
+			grue.ThemeButtonHL:       btmdhl,
+			grue.ThemeLineEdit:       lemd,
+			grue.ThemeLineEditHL:     lemdhl,
+			grue.ThemeComboBox:       btmd,
+			grue.ThemeComboBoxHL:     btmdhl,
+			grue.ThemeComboBoxActive: btmda,
+			grue.ThemeComboBoxArrow: ArrowDrawer{
+				Color: grue.RGB(0.9, 0.7, 0.55),
+			},
+			grue.ThemeListView: lemd,
+			grue.ThemeListViewRowHL: PlainRect{
+				BackColor: grue.RGBA(1, 1, 1, 0.1),
+			},
+			grue.ThemeListViewRowSelected: PlainRect{
+				BackColor: grue.RGBA(0.9, 0.7, 0.55, 0.35),
+			},
+			grue.ThemeListViewRowCurrent: PlainRect{
+				BorderColor: grue.RGB(0.9, 0.7, 0.55),
+				BorderSize:  1,
+			},
+			grue.ThemeTreeViewExpanded: ArrowDrawer{
+				Color: grue.RGB(0.9, 0.7, 0.55),
+			},
+			grue.ThemeTreeViewCollapsed: ArrowDrawer{
+				Color:     grue.RGB(0.9, 0.7, 0.55),
+				Direction: grue.AlignRight,
+			},
+			grue.ThemeTreeViewGuide: PlainRect{
+				BackColor: grue.RGBA(0.9, 0.7, 0.55, 0.4),
+			},
+			grue.ThemeListViewRowAlt: PlainRect{
+				BackColor: grue.RGBA(0, 0, 0, 0.15),
+			},
+			grue.ThemeTableHeader:   btmd,
+			grue.ThemeTableHeaderHL: btmdhl,
+			grue.ThemeTableSortAsc: ArrowDrawer{
+				Color:     grue.RGB(0.9, 0.7, 0.55),
+				Direction: grue.AlignTop,
+			},
+			grue.ThemeTableSortDesc: ArrowDrawer{
+				Color: grue.RGB(0.9, 0.7, 0.55),
+			},
+			grue.ThemeTab:       btmd,
+			grue.ThemeTabHL:     btmdhl,
+			grue.ThemeTabActive: btmda,
+			grue.ThemeTabClose: CrossDrawer{
+				Color: grue.RGB(0.9, 0.7, 0.55),
+			},
+			grue.ThemeTabScrollLeft: ArrowDrawer{
+				Color:     grue.RGB(0.9, 0.7, 0.55),
+				Direction: grue.AlignLeft,
+			},
+			grue.ThemeTabScrollRight: ArrowDrawer{
+				Color:     grue.RGB(0.9, 0.7, 0.55),
+				Direction: grue.AlignRight,
+			},
+			grue.ThemeProgressGroove: lemd,
+			grue.ThemeProgressFill: TexturedPanel{
+				Image:          "stone-bt",
+				TileHorizontal: true, TileVertical: true,
+				Color: grue.RGB(0.6, 0.9, 0.6),
+			},
+			grue.ThemeDialog:      pnmd,
+			grue.ThemeDialogTitle: btmd,
+			grue.ThemeDialogDim: PlainRect{
+				BackColor: grue.RGBA(0, 0, 0, 0.5),
+			},
+			grue.ThemeWindow:            pnmd,
+			grue.ThemeWindowTitle:       btmda,
+			grue.ThemeWindowTitleActive: btmd,
+			grue.ThemeWindowClose: CrossDrawer{
+				Color: grue.RGB(0.9, 0.7, 0.55),
+			},
+			grue.ThemeWindowMinimize: ArrowDrawer{
+				Color:     grue.RGB(0.9, 0.7, 0.55),
+				Direction: grue.AlignTop,
+			},
+			grue.ThemeMenuBar:           btmda,
+			grue.ThemeMenuBarItemHL:     btmd,
+			grue.ThemeMenuBarItemActive: btmd,
+			grue.ThemeMenuSeparator: PlainRect{
+				BackColor: grue.RGB(0.9, 0.7, 0.55),
+			},
+			grue.ThemeMenuCheck: CheckDrawer{
+				Color: grue.RGB(0.9, 0.7, 0.55),
+			},
+			grue.ThemeMenuRadio: CheckDrawer{
+				Color: grue.RGB(0.9, 0.7, 0.55),
+				Radio: true,
+			},
+			grue.ThemeMenuSubmenu: ArrowDrawer{
+				Color:     grue.RGB(0.9, 0.7, 0.55),
+				Direction: grue.AlignRight,
+			},
+			grue.ThemeToolbar: btmda,
+			grue.ThemeToolbarSeparator: PlainRect{
+				BackColor: grue.RGB(0.9, 0.7, 0.55),
+			},
+			grue.ThemeToolbarOverflow: ArrowDrawer{
+				Color: grue.RGB(0.9, 0.7, 0.55),
+			},
+			grue.ThemeStatusBar: btmda,
+			grue.ThemeStatusSection: PlainRect{
+				BorderColor: grue.RGBA(0, 0, 0, 0.3),
+				BorderSize:  1,
+			},
+			grue.ThemeToastInfo:    toast(grue.RGB(0.8, 0.9, 1)),
+			grue.ThemeToastWarning: toast(grue.RGB(1, 0.9, 0.6)),
+			grue.ThemeToastError:   toast(grue.RGB(1, 0.6, 0.6)),
+			grue.ThemeColorPickerFrame: PlainRect{
+				BorderColor: grue.RGB(0.9, 0.7, 0.55),
+				BorderSize:  1,
+			},
+			grue.ThemeColorPickerMarker: grue.MultiDrawer{Drawers: []grue.ThemeDrawer{
+				PlainRect{
+					BorderColor: grue.RGB(0, 0, 0),
+					BorderSize:  1,
+				},
+				PlainRect{
+					BorderColor: grue.RGB(1, 1, 1),
+					BorderSize:  1,
+					BorderInset: 1,
+				},
+			}},
+			grue.ThemeFocusRing: PlainRect{
+				BorderColor: grue.RGB(1, 0.9, 0.6),
+				BorderSize:  2,
+				BorderInset: -2,
+			},
+			grue.ThemeSplitterHandle: PlainRect{
+				BackColor: grue.RGBA(0, 0, 0, 0.3),
+			},
+			grue.ThemeSplitterHandleHL: PlainRect{
+				BackColor: grue.RGB(0.9, 0.7, 0.55),
+			},
+			grue.ThemeScrollBar: PlainRect{
+				BackColor: grue.RGBA(0, 0, 0, 0.3),
+			},
+			grue.ThemeScrollBarThumb: PlainRect{
+				BackColor: grue.RGB(0.9, 0.7, 0.55),
+			},
 			grue.ThemeTooltip: PlainRect{
 				BackColor:   grue.RGB(1, 0.95, 0.8),
 				BorderColor: grue.RGB(0, 0, 0),
@@ -0,0 +1,245 @@
+package grue
+
+import "math"
+
+// Severity of notification.
+type Severity int
+
+// Notification severities.
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityError
+)
+
+// Toast is transient notification shown by ToastQueue.
+// It fades in, stays for Duration seconds, then fades out
+// (by changing Transparency).
+// Click dismisses toast.
+type Toast struct {
+	*Panel
+	Severity Severity
+	// Duration is time (seconds) toast is fully shown.
+	Duration float64
+
+	queue *ToastQueue
+	// Seconds since toast is shown.
+	age float64
+	// Age toast starts fading out at.
+	fadeOut float64
+}
+
+// Dismiss starts fading out toast.
+func (t *Toast) Dismiss() {
+	fadeOut := t.age
+	if in := t.queue.FadeTime; t.age < in {
+		// Fade out from current opacity.
+		fadeOut -= in - t.age
+	}
+	t.fadeOut = math.Min(t.fadeOut, fadeOut)
+}
+
+// Alpha returns current opacity of toast from 0 to 1.
+func (t *Toast) Alpha() float64 {
+	ft := t.queue.FadeTime
+	if ft <= 0 {
+		if t.age >= t.fadeOut {
+			return 0
+		}
+		return 1
+	}
+	a := math.Min(t.age/ft, 1)
+	if t.age > t.fadeOut {
+		a = math.Min(a, 1-(t.age-t.fadeOut)/ft)
+	}
+	return math.Max(a, 0)
+}
+
+// Render draws toast with its background, image and text
+// faded according to its age.
+func (t *Toast) Render() {
+	t.Transparency = 1 - t.Alpha()
+	t.Panel.Render()
+}
+
+// Paint draws the widget without children.
+func (t *Toast) Paint() {
+	theme := t.MyTheme()
+	key := ThemeToastInfo
+	switch t.Severity {
+	case SeverityWarning:
+		key = ThemeToastWarning
+	case SeverityError:
+		key = ThemeToastError
+	}
+	if td, _ := theme.Drawers[key]; td != nil {
+		td.Draw(t.Surface, t.GlobalRect(), t.Extras...)
+	}
+	tcol := theme.PanelTextColor
+	if tcol == nil {
+		tcol = theme.TextColor
+	}
+	t.DrawImageAndText(t.Image, t.Text, tcol, t.ImageAlign, t.TextAlign, Vec{})
+}
+
+// ToastQueue shows toasts stacked in a corner of surface,
+// the newest one is the closest to corner.
+// It covers the whole surface, but only toasts receive input.
+// Use Notify to show toasts in surface queue.
+type ToastQueue struct {
+	*Panel
+	// Corner is AlignBottomRight (default), AlignBottomLeft,
+	// AlignTopRight or AlignTopLeft.
+	Corner Align
+	// Duration is default time (seconds) toast is fully shown.
+	Duration float64
+	// FadeTime is time (seconds) of fading in and out.
+	FadeTime float64
+	// Width of toasts. Height is derived from font height.
+	Width float64
+	// MaxToasts is maximum number of toasts shown at once,
+	// older toasts are dismissed.
+	MaxToasts int
+
+	toasts []*Toast
+}
+
+// NewToastQueue creates new toast queue covering surface.
+func NewToastQueue(s Surface) *ToastQueue {
+	root := s.Root()
+	q := &ToastQueue{
+		Panel: NewPanel(nil, Base{
+			Rect: R0(root.GetPanel().Rect.W(), root.GetPanel().Rect.H()),
+		}),
+		Corner:    AlignBottomRight,
+		Duration:  3,
+		FadeTime:  0.3,
+		Width:     300,
+		MaxToasts: 5,
+	}
+	InitWidget(root, q)
+	return q
+}
+
+// Notify shows toast in the queue of surface (queue is created
+// if there is none). Image may be empty.
+func Notify(s Surface, sev Severity, text, image string) *Toast {
+	var q *ToastQueue
+	for _, ch := range s.Root().GetPanel().Children {
+		if tq, ok := ch.(*ToastQueue); ok {
+			q = tq
+			break
+		}
+	}
+	if q == nil {
+		q = NewToastQueue(s)
+	}
+	return q.Show(sev, text, image)
+}
+
+// Show adds new toast and raises queue above other widgets.
+func (q *ToastQueue) Show(sev Severity, text, image string) *Toast {
+	theme := q.MyTheme()
+	h := q.Surface.GetTextRect("Wg", theme.TitleFont).H() + theme.Pad*2
+	t := &Toast{
+		Panel: NewPanel(nil, Base{
+			Rect:      R0(q.Width, h),
+			Text:      text,
+			Image:     image,
+			TextAlign: AlignLeft,
+		}),
+		Severity: sev,
+		Duration: q.Duration,
+		queue:    q,
+		fadeOut:  math.Inf(1),
+	}
+	InitWidget(q, t)
+	t.fadeOut = q.FadeTime + t.Duration
+	t.OnMouseClick = func(Button) {
+		t.Dismiss()
+	}
+	q.toasts = append(q.toasts, t)
+	if n := len(q.toasts) - q.MaxToasts; q.MaxToasts > 0 && n > 0 {
+		for _, old := range q.toasts[:n] {
+			old.Dismiss()
+		}
+	}
+	q.Raise()
+	q.layout()
+	return t
+}
+
+// WidgetUnder returns toast under pointer (or its child),
+// the queue itself doesn't intercept input.
+func (q *ToastQueue) WidgetUnder(pos Vec) Widget {
+	wu := q.Panel.WidgetUnder(pos)
+	if q.Equals(wu) {
+		return nil
+	}
+	return wu
+}
+
+// ProcessMouse passes mouse to toasts only. The queue itself
+// doesn't generate events nor set tooltip, so widgets it
+// covers keep their tooltips.
+func (q *ToastQueue) ProcessMouse(wu Widget) {
+	if q.Hidden {
+		return
+	}
+	// Make a copy of children because
+	// ProcessMouse may modify array.
+	pch := make([]Widget, len(q.Children))
+	copy(pch, q.Children)
+	for _, c := range pch {
+		c.ProcessMouse(wu)
+	}
+}
+
+// Stack toasts from the corner.
+func (q *ToastQueue) layout() {
+	pad := q.MyTheme().Pad
+	left := q.Corner == AlignBottomLeft || q.Corner == AlignTopLeft
+	top := q.Corner == AlignTopRight || q.Corner == AlignTopLeft
+	y := pad
+	if top {
+		y = q.Rect.H() - pad
+	}
+	for i := len(q.toasts) - 1; i >= 0; i-- {
+		t := q.toasts[i]
+		w, h := t.Rect.W(), t.Rect.H()
+		x := q.Rect.W() - pad - w
+		if left {
+			x = pad
+		}
+		if top {
+			t.Rect = R(x, y-h, x+w, y)
+			y -= h + pad
+		} else {
+			t.Rect = R(x, y, x+w, y+h)
+			y += h + pad
+		}
+	}
+}
+
+// Paint advances toasts and removes faded out ones.
+func (q *ToastQueue) Paint() {
+	if q.Parent != nil {
+		pr := q.Parent.GetPanel().Rect
+		q.Rect = R0(pr.W(), pr.H())
+	}
+	dt := q.Surface.FrameTime()
+	alive := q.toasts[:0]
+	for _, t := range q.toasts {
+		t.age += dt
+		if t.age > t.fadeOut && t.Alpha() <= 0 {
+			t.Close()
+			continue
+		}
+		alive = append(alive, t)
+	}
+	for i := len(alive); i < len(q.toasts); i++ {
+		q.toasts[i] = nil
+	}
+	q.toasts = alive
+	q.layout()
+}
@@ -0,0 +1,246 @@
+package grue
+
+import "math"
+
+// ToolItem is one item of toolbar.
+type ToolItem struct {
+	// Button of item, nil for separators and spacers.
+	Button *PushButton
+	// Separator is drawn as a line between buttons.
+	Separator bool
+	// Spacer takes free space of toolbar, so following
+	// items are pushed to the right.
+	Spacer bool
+
+	// Item doesn't fit into toolbar and is shown in overflow menu.
+	hidden bool
+}
+
+// Toolbar is a row of icon buttons. Items that don't fit
+// into toolbar width are moved to overflow menu opened
+// by button at the right end. Item buttons are sized
+// and placed by toolbar.
+type Toolbar struct {
+	*Panel
+	Items []*ToolItem
+	// ButtonSize is width and height of buttons.
+	// If zero, toolbar height minus Pad is used.
+	ButtonSize float64
+
+	overflow *toolOverflow
+	// Separator rectangles (relative to toolbar).
+	seps []Rect
+}
+
+// NewToolbar creates new empty toolbar.
+func NewToolbar(parent Widget, b Base) *Toolbar {
+	tb := &Toolbar{
+		Panel: NewPanel(nil, b),
+	}
+	InitWidget(parent, tb)
+	return tb
+}
+
+// AddButton adds icon button showing image (sprite name)
+// with tooltip.
+func (tb *Toolbar) AddButton(image, tooltip string, onPress func()) *PushButton {
+	bt := NewPushButton(tb, Base{
+		Image:      image,
+		ImageAlign: AlignCenter,
+		Tooltip:    tooltip,
+	})
+	bt.OnPress = onPress
+	tb.Items = append(tb.Items, &ToolItem{Button: bt})
+	tb.Layout()
+	return bt
+}
+
+// AddToggle adds checkable icon button. Handler receives
+// new state of the button.
+func (tb *Toolbar) AddToggle(image, tooltip string, onToggle func(checked bool)) *PushButton {
+	bt := tb.AddButton(image, tooltip, nil)
+	bt.Checkable = true
+	bt.OnPress = func() {
+		if onToggle != nil {
+			onToggle(bt.Checked)
+		}
+	}
+	return bt
+}
+
+// AddSeparator adds separator line.
+func (tb *Toolbar) AddSeparator() {
+	tb.Items = append(tb.Items, &ToolItem{Separator: true})
+	tb.Layout()
+}
+
+// AddSpacer adds flexible space.
+func (tb *Toolbar) AddSpacer() {
+	tb.Items = append(tb.Items, &ToolItem{Spacer: true})
+	tb.Layout()
+}
+
+func (tb *Toolbar) buttonSize() float64 {
+	if tb.ButtonSize > 0 {
+		return tb.ButtonSize
+	}
+	return tb.Rect.H() - tb.MyTheme().Pad
+}
+
+// Returns width of item.
+func (tb *Toolbar) itemWidth(it *ToolItem) float64 {
+	switch {
+	case it.Spacer:
+		return 0
+	case it.Separator:
+		return tb.MyTheme().Pad
+	}
+	return tb.buttonSize()
+}
+
+// Layout places items and moves those that don't fit
+// to overflow menu. Toolbar does it every frame, call it
+// to have button rects updated right away.
+func (tb *Toolbar) Layout() {
+	pad := tb.MyTheme().Pad / 2
+	bs := tb.buttonSize()
+	avail := tb.Rect.W() - pad
+	total := 0.0
+	spacers := 0
+	for _, it := range tb.Items {
+		total += tb.itemWidth(it) + pad
+		if it.Spacer {
+			spacers++
+		}
+	}
+	overflow := total > avail
+	if overflow {
+		avail -= bs + pad
+	}
+
+	y := math.Floor((tb.Rect.H() - bs) / 2)
+	x := pad
+	hidden := false
+	tb.seps = tb.seps[:0]
+	for _, it := range tb.Items {
+		w := tb.itemWidth(it)
+		if it.Spacer && !overflow {
+			w = (avail - total) / float64(spacers)
+		}
+		// Once item doesn't fit, all the following are hidden.
+		hidden = hidden || x+w > avail
+		if it.Button != nil {
+			it.Button.SetHidden(hidden)
+		}
+		it.hidden = hidden
+		if hidden {
+			continue
+		}
+		switch {
+		case it.Separator:
+			tb.seps = append(tb.seps, R(x, y, x+w, y+bs))
+		case it.Button != nil:
+			it.Button.Rect = R(x, y, x+w, y+bs)
+		}
+		x += w + pad
+	}
+
+	switch {
+	case overflow && tb.overflow == nil:
+		tb.overflow = newToolOverflow(tb)
+	case !overflow && tb.overflow != nil:
+		tb.overflow.Close()
+		tb.overflow = nil
+	}
+	if tb.overflow != nil {
+		x := tb.Rect.W() - pad - bs
+		tb.overflow.Rect = R(x, y, x+bs, y+bs)
+	}
+}
+
+// Show menu with items hidden due to overflow. Menu
+// has no mnemonics, so tooltips are shown as is.
+func (tb *Toolbar) openOverflow() {
+	var opts []MenuOption
+	for _, it := range tb.Items {
+		if !it.hidden || it.Spacer {
+			continue
+		}
+		if it.Separator {
+			if len(opts) > 0 {
+				opts = append(opts, MenuOption{Separator: true})
+			}
+			continue
+		}
+		bt := it.Button
+		text := bt.Tooltip
+		if text == "" {
+			text = bt.Image
+		}
+		opts = append(opts, MenuOption{
+			Text:      text,
+			Image:     bt.Image,
+			Disabled:  bt.IsDisabled(),
+			Checkable: bt.Checkable,
+			Checked:   bt.Checked,
+			Handler: func(*PopupMenu) bool {
+				bt.Press()
+				return true
+			},
+		})
+	}
+	if len(opts) == 0 {
+		return
+	}
+	s := tb.Surface
+	theme := tb.MyTheme()
+	step := menuStep(s, theme)
+	pm := NewPopupMenu(s.Root(), Base{
+		Theme: tb.Theme,
+		Rect:  R0(menuWidth(s, theme, step, opts, false), step),
+	}, opts...)
+	pm.PlaceAt(tb.overflow.GlobalRect(), SideBelow)
+	s.SetFocus(pm)
+}
+
+// Paint draws the widget without children.
+func (tb *Toolbar) Paint() {
+	tb.Layout()
+	theme := tb.MyTheme()
+	r := tb.GlobalRect()
+	if td, _ := theme.Drawers[ThemeToolbar]; td != nil {
+		td.Draw(tb.Surface, r, tb.Extras...)
+	}
+	if td, _ := theme.Drawers[ThemeToolbarSeparator]; td != nil {
+		for _, sr := range tb.seps {
+			c := math.Floor(sr.Center().X)
+			td.Draw(tb.Surface, R(c-1, sr.Min.Y, c+1, sr.Max.Y).Moved(r.Min), tb.Extras...)
+		}
+	}
+}
+
+// toolOverflow is a button opening menu with hidden toolbar items.
+type toolOverflow struct {
+	*PushButton
+}
+
+func newToolOverflow(tb *Toolbar) *toolOverflow {
+	ov := &toolOverflow{
+		PushButton: NewPushButton(nil, Base{}),
+	}
+	InitWidget(tb, ov)
+	ov.OnPress = tb.openOverflow
+	return ov
+}
+
+// Paint draws the widget without children.
+func (ov *toolOverflow) Paint() {
+	td, _, disp := ov.look()
+	r := ov.GlobalRect()
+	if td != nil {
+		td.Draw(ov.Surface, r, ov.Extras...)
+	}
+	if td, _ := ov.MyTheme().Drawers[ThemeToolbarOverflow]; td != nil {
+		td.Draw(ov.Surface, r.Moved(disp), ov.Extras...)
+	}
+}
@@ -0,0 +1,328 @@
+package grue
+
+import (
+	"fmt"
+	"image/color"
+)
+
+// TreeNode is a node of tree view.
+type TreeNode struct {
+	ID    string
+	Text  string
+	Image string
+	// Data is arbitrary user data attached to node.
+	Data interface{}
+
+	Parent   *TreeNode
+	Children []*TreeNode
+	Expanded bool
+	// HasChildren marks node as expandable before children
+	// are loaded (see TreeView.LoadChildren).
+	HasChildren bool
+
+	loaded bool
+}
+
+// Add appends child node and returns it.
+func (n *TreeNode) Add(ch *TreeNode) *TreeNode {
+	ch.Parent = n
+	n.Children = append(n.Children, ch)
+	return ch
+}
+
+// Clear removes all children. If node has HasChildren set,
+// children will be loaded again on expansion.
+func (n *TreeNode) Clear() {
+	for _, ch := range n.Children {
+		ch.Parent = nil
+	}
+	n.Children = nil
+	n.loaded = false
+}
+
+// Expandable returns true if node has (or may have) children.
+func (n *TreeNode) Expandable() bool {
+	return len(n.Children) > 0 || (n.HasChildren && !n.loaded)
+}
+
+// treeRows is list model of visible tree nodes.
+type treeRows []*TreeNode
+
+// Len ...
+func (tr treeRows) Len() int {
+	return len(tr)
+}
+
+// Item ...
+func (tr treeRows) Item(i int) ListItem {
+	return ListItem{ID: tr[i].ID, Text: tr[i].Text, Image: tr[i].Image}
+}
+
+// TreeView displays hierarchical data. It is a ListView
+// which rows are visible (i.e. having all parents expanded)
+// nodes of the tree.
+// OnSelectionChanged and OnActivate of embedded ListView
+// are used by tree view, use OnNodeSelected and
+// OnNodeActivate instead.
+// After modifying nodes, call Refresh.
+type TreeView struct {
+	*ListView
+	// Root node is not shown, its children are top level nodes.
+	Root *TreeNode
+	// Indent is horizontal shift of each level.
+	// If zero, row height is used.
+	Indent float64
+
+	// LoadChildren is called when node having HasChildren
+	// is expanded for the first time.
+	LoadChildren func(n *TreeNode)
+
+	OnNodeSelected func(n *TreeNode)
+	OnNodeActivate func(n *TreeNode)
+	OnNodeExpanded func(n *TreeNode, expanded bool)
+
+	rows   treeRows
+	depths []int
+}
+
+// NewTreeView creates new tree view.
+func NewTreeView(parent Widget, b Base, root *TreeNode) *TreeView {
+	if root == nil {
+		root = &TreeNode{}
+	}
+	tv := &TreeView{
+		ListView: NewListView(nil, b, nil),
+		Root:     root,
+	}
+	InitWidget(parent, tv)
+	tv.FocusPolicy = FocusStrong
+	tv.drawRow = tv.drawNode
+	tv.Root.Expanded = true
+
+	tv.OnSelectionChanged = func() {
+		if tv.OnNodeSelected != nil {
+			tv.OnNodeSelected(tv.CurrentNode())
+		}
+	}
+	tv.OnActivate = func(i int) {
+		n := tv.rows[i]
+		if n.Expandable() {
+			tv.SetExpanded(n, !n.Expanded)
+		}
+		if tv.OnNodeActivate != nil {
+			tv.OnNodeActivate(n)
+		}
+	}
+	tv.OnMouseDown = func(bt Button) {
+		pos := tv.Surface.MousePos()
+		i := tv.RowAt(pos)
+		if bt == MouseButtonLeft && !tv.IsDisabled() && i >= 0 &&
+			tv.rows[i].Expandable() && tv.expanderRect(i).Contains(pos) {
+			tv.SetExpanded(tv.rows[i], !tv.rows[i].Expanded)
+			return
+		}
+		tv.onMouseDown(bt)
+	}
+	tv.OnKeys = tv.onTreeKeys
+	tv.Refresh()
+	return tv
+}
+
+// Refresh rebuilds the list of visible nodes.
+// Selection of nodes that remain visible is kept.
+func (tv *TreeView) Refresh() {
+	sel := tv.SelectedNodes()
+	cur := tv.CurrentNode()
+	var anchor *TreeNode
+	if tv.anchor >= 0 && tv.anchor < len(tv.rows) {
+		anchor = tv.rows[tv.anchor]
+	}
+
+	tv.rows = tv.rows[:0]
+	tv.depths = tv.depths[:0]
+	tv.flatten(tv.Root, -1)
+	tv.Model = tv.rows
+
+	tv.ClearSelection()
+	tv.Current = -1
+	tv.anchor = 0
+	for i, n := range tv.rows {
+		for _, s := range sel {
+			if s == n {
+				tv.Selected[i] = true
+			}
+		}
+		if n == cur {
+			tv.Current = i
+		}
+		if n == anchor {
+			tv.anchor = i
+		}
+	}
+	tv.Scroll(0)
+}
+
+func (tv *TreeView) flatten(n *TreeNode, depth int) {
+	if depth >= 0 {
+		tv.rows = append(tv.rows, n)
+		tv.depths = append(tv.depths, depth)
+	}
+	if !n.Expanded {
+		return
+	}
+	tv.load(n)
+	for _, ch := range n.Children {
+		tv.flatten(ch, depth+1)
+	}
+}
+
+func (tv *TreeView) load(n *TreeNode) {
+	if n.loaded || !n.HasChildren {
+		return
+	}
+	n.loaded = true
+	if tv.LoadChildren != nil {
+		tv.LoadChildren(n)
+	}
+}
+
+// SetExpanded expands or collapses node.
+func (tv *TreeView) SetExpanded(n *TreeNode, expanded bool) {
+	if n.Expanded == expanded {
+		return
+	}
+	n.Expanded = expanded
+	tv.Refresh()
+	if tv.OnNodeExpanded != nil {
+		tv.OnNodeExpanded(n, expanded)
+	}
+}
+
+// CurrentNode returns node with keyboard cursor (nil if none).
+func (tv *TreeView) CurrentNode() *TreeNode {
+	if tv.Current < 0 || tv.Current >= len(tv.rows) {
+		return nil
+	}
+	return tv.rows[tv.Current]
+}
+
+// SelectedNodes returns list of selected visible nodes.
+func (tv *TreeView) SelectedNodes() []*TreeNode {
+	var res []*TreeNode
+	for _, i := range tv.SelectedIndices() {
+		if i < len(tv.rows) {
+			res = append(res, tv.rows[i])
+		}
+	}
+	return res
+}
+
+// SelectNode expands node parents, makes it current
+// and scrolls to it.
+func (tv *TreeView) SelectNode(n *TreeNode) {
+	for p := n.Parent; p != nil; p = p.Parent {
+		p.Expanded = true
+	}
+	tv.Refresh()
+	for i, r := range tv.rows {
+		if r == n {
+			tv.moveTo(i)
+			return
+		}
+	}
+}
+
+func (tv *TreeView) indent() float64 {
+	if tv.Indent > 0 {
+		return tv.Indent
+	}
+	return tv.rowHeight()
+}
+
+// Rectangle of expand/collapse indicator of row (screen coords).
+func (tv *TreeView) expanderRect(i int) Rect {
+	r := tv.RowRect(i)
+	ind := tv.indent()
+	x := r.Min.X + float64(tv.depths[i])*ind
+	return R(x, r.Min.Y, x+ind, r.Max.Y)
+}
+
+func (tv *TreeView) drawNode(i int, r Rect, textColor color.Color) {
+	n := tv.rows[i]
+	theme := tv.MyTheme()
+	ind := tv.indent()
+	if td, _ := theme.Drawers[ThemeTreeViewGuide]; td != nil {
+		for k := 0; k < tv.depths[i]; k++ {
+			x := r.Min.X + float64(k)*ind + ind/2
+			td.Draw(tv.Surface, R(x, r.Min.Y, x+1, r.Max.Y), tv.Extras...)
+		}
+	}
+	er := tv.expanderRect(i)
+	if n.Expandable() {
+		key := ThemeTreeViewCollapsed
+		if n.Expanded {
+			key = ThemeTreeViewExpanded
+		}
+		if td, _ := theme.Drawers[key]; td != nil {
+			td.Draw(tv.Surface, er, tv.Extras...)
+		}
+	}
+	tr := r
+	tr.Min.X = er.Max.X - theme.Pad
+	tv.DrawImageAndTextIn(tr, n.Image, n.Text, textColor, AlignLeft, AlignLeft, Vec{})
+}
+
+func (tv *TreeView) onTreeKeys() bool {
+	if tv.IsDisabled() || !tv.Equals(tv.Surface.Focus()) {
+		return false
+	}
+	s := tv.Surface
+	n := tv.CurrentNode()
+	switch {
+	case n == nil:
+	case s.JustPressed(KeyRight) || s.Repeated(KeyRight):
+		if !n.Expanded && n.Expandable() {
+			tv.SetExpanded(n, true)
+		} else if n.Expanded && len(n.Children) > 0 {
+			tv.moveTo(tv.Current + 1)
+		}
+		return true
+	case s.JustPressed(KeyLeft) || s.Repeated(KeyLeft):
+		if n.Expanded && n.Expandable() {
+			tv.SetExpanded(n, false)
+		} else if n.Parent != nil && n.Parent != tv.Root {
+			tv.SelectNode(n.Parent)
+		}
+		return true
+	}
+	return tv.onKeys()
+}
+
+// WidgetTreeNode returns tree node representing widget.
+// Children of node are widget's children, they are loaded
+// by LoadWidgetChildren, which should be set as tree view's
+// LoadChildren. Node's Data holds the widget.
+func WidgetTreeNode(w Widget) *TreeNode {
+	p := w.GetPanel()
+	text := fmt.Sprintf("%T", p.Virt)
+	if p.Text != "" {
+		text += fmt.Sprintf(" %q", p.Text)
+	}
+	return &TreeNode{
+		Text:        text,
+		Data:        w,
+		HasChildren: len(p.Children) > 0,
+	}
+}
+
+// LoadWidgetChildren adds nodes for children of
+// widget held in node's Data (see WidgetTreeNode).
+func LoadWidgetChildren(n *TreeNode) {
+	w, ok := n.Data.(Widget)
+	if !ok {
+		return
+	}
+	for _, ch := range w.GetPanel().Children {
+		n.Add(WidgetTreeNode(ch))
+	}
+}